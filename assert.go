@@ -2,8 +2,10 @@ package assert
 
 import (
 	"errors"
+	"fmt"
 	"reflect"
 	"regexp"
+	"sync"
 	"testing"
 )
 
@@ -20,10 +22,12 @@ func Fatal(t testing.TB) *FatalTB {
 }
 
 func (t *FatalTB) Error(args ...any) {
+	t.TB.Helper()
 	t.TB.Fatal(args...)
 }
 
 func (t *FatalTB) Errorf(format string, args ...any) {
+	t.TB.Helper()
 	t.TB.Fatalf(format, args...)
 }
 
@@ -52,11 +56,17 @@ func ErrorIs(t testing.TB, err, target error) {
 	}
 }
 
-// Equal asserts that two comparable values are equivalent.
+// Equal asserts that two comparable values are equivalent. Structs and arrays
+// that are not equal are reported field by field.
 func Equal[T comparable](t testing.TB, got, expected T) {
 	if expected != got {
 		t.Helper()
-		t.Errorf(`expected "%v", got "%v"`, expected, got)
+		switch reflect.ValueOf(expected).Kind() {
+		case reflect.Struct, reflect.Array:
+			t.Errorf("values are not equal:\n%s", indent(equalReport(expected, got), "\t"))
+		default:
+			t.Errorf(`expected "%v", got "%v"`, expected, got)
+		}
 	}
 }
 
@@ -69,11 +79,12 @@ func NotEqual[T comparable](t testing.TB, got, expected T) {
 }
 
 // DeepEqual asserts that two comparable values are equivalent using
-// reflect.DeepEqual.
+// reflect.DeepEqual. On failure, only the paths at which the values differ are
+// reported.
 func DeepEqual[T, R any](t testing.TB, got T, expected R) {
-	if !reflect.DeepEqual(got, expected) {
+	if diff := diffValues(expected, got); diff != "" {
 		t.Helper()
-		t.Errorf(`expected "%+v", got "%+v"`, expected, got)
+		t.Errorf("values are not equal:\n%s", indent(diff, "\t"))
 	}
 }
 
@@ -82,8 +93,18 @@ func DeepEqual[T, R any](t testing.TB, got T, expected R) {
 func NotDeepEqual[T, R any](t testing.TB, got T, expected R) {
 	if reflect.DeepEqual(got, expected) {
 		t.Helper()
-		t.Errorf(`expect "%v" to not equal "%v"`, got, expected)
+		t.Errorf("expected %s to not equal %s", formatValue(got), formatValue(expected))
+	}
+}
+
+// equalReport describes how got differs from expected. Unlike diffValues, a
+// report is always produced, even for values that are deeply equal but not
+// equal according to ==, such as distinct pointers to equal values.
+func equalReport(expected, got any) string {
+	if diff := diffValues(expected, got); diff != "" {
+		return diff
 	}
+	return fmt.Sprintf("expected %s, got %s", formatValue(expected), formatValue(got))
 }
 
 // Ordered represents all types that support the <, <=, >=, and > operators.
@@ -126,34 +147,14 @@ func LessThanOrEqual[T Ordered](t testing.TB, got, expected T) {
 	}
 }
 
-// SliceContains asserts that a slice contains one or more values.
-func SliceContains[T comparable](t testing.TB, slice []T, values ...T) {
-	for _, v := range values {
-		sliceContains(t, slice, v)
-	}
-}
-
-// sliceContains is a private helper for checking the existence of a single
-// element in a slice.
-func sliceContains[T comparable](t testing.TB, slice []T, value T) {
-	for _, s := range slice {
-		if s == value {
-			return
-		}
-	}
-
-	t.Helper()
-	t.Errorf(`slice does not contain value "%v"`, value)
-}
-
 // MapContains asserts that a map contains the provided key-value pair.
 func MapContains[K, V comparable](t testing.TB, m map[K]V, key K, value V) {
 	t.Helper()
 	v, ok := m[key]
 	if !ok {
-		t.Errorf(`map does not contain key-value pair "%v: %v"`, key, value)
+		t.Errorf("map does not contain key-value pair %s: %s", formatValue(key), formatValue(value))
 	} else if v != value {
-		t.Errorf(`map contains key %v but not value %v`, key, value)
+		t.Errorf("map contains key %s but its value is not equal:\n%s", formatValue(key), indent(equalReport(value, v), "\t"))
 	}
 }
 
@@ -171,21 +172,18 @@ func MapContainsKey[K comparable, V any](t testing.TB, m map[K]V, keys ...K) {
 // is used as the key in the map.
 var regexCache = make(map[string]*regexp.Regexp)
 
+// regexMu guards regexCache.
+var regexMu sync.Mutex
+
 // RegexMatches asserts that a provided string is matched by the provided pattern.
 // In order to avoid compiling regular expressions many times, they are compiled
 // once and cached for future use.
 func RegexMatches(t testing.TB, got string, pattern string) {
-	r, ok := regexCache[pattern]
-	if !ok {
-		var err error
-		r, err = regexp.Compile(pattern)
-		if err != nil {
-			t.Helper()
-			t.Fatalf("failed to compile regular expression: %v", err)
-			return
-		}
-
-		regexCache[pattern] = r
+	r, err := compileRegex(pattern)
+	if err != nil {
+		t.Helper()
+		t.Fatalf("failed to compile regular expression: %v", err)
+		return
 	}
 
 	if !r.MatchString(got) {
@@ -193,3 +191,22 @@ func RegexMatches(t testing.TB, got string, pattern string) {
 		t.Errorf("received string %s not matched by pattern /%s/", got, pattern)
 	}
 }
+
+// compileRegex returns the compiled form of a pattern. Patterns are only
+// compiled the first time they are seen and are then stored in regexCache.
+func compileRegex(pattern string) (*regexp.Regexp, error) {
+	regexMu.Lock()
+	defer regexMu.Unlock()
+
+	if r, ok := regexCache[pattern]; ok {
+		return r, nil
+	}
+
+	r, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	regexCache[pattern] = r
+	return r, nil
+}
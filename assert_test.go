@@ -15,6 +15,8 @@ type mockTB struct {
 	FatalCalls  []messageParams
 	FatalfCalls []formattedMessageParams
 	HelperCalls int
+
+	CleanupFuncs []func()
 }
 
 type messageParams struct {
@@ -26,6 +28,11 @@ type formattedMessageParams struct {
 	args   []any
 }
 
+// message returns the formatted failure message.
+func (p formattedMessageParams) message() string {
+	return fmt.Sprintf(p.format, p.args...)
+}
+
 func newMockTB() *mockTB {
 	return &mockTB{
 		T:           &testing.T{},
@@ -43,6 +50,7 @@ func (t *mockTB) Reset() {
 	t.FatalCalls = []messageParams{}
 	t.FatalfCalls = []formattedMessageParams{}
 	t.HelperCalls = 0
+	t.CleanupFuncs = nil
 }
 
 func (t *mockTB) Error(args ...any) {
@@ -58,13 +66,26 @@ func (t *mockTB) Fatal(args ...any) {
 }
 
 func (t *mockTB) Fatalf(format string, args ...any) {
-	t.FatalfCalls = append(t.FatalfCalls, formattedMessageParams{args: args})
+	t.FatalfCalls = append(t.FatalfCalls, formattedMessageParams{format: format, args: args})
 }
 
 func (t *mockTB) Helper() {
 	t.HelperCalls++
 }
 
+func (t *mockTB) Cleanup(f func()) {
+	t.CleanupFuncs = append(t.CleanupFuncs, f)
+}
+
+// RunCleanups calls the registered cleanup functions in last added, first
+// called order.
+func (t *mockTB) RunCleanups() {
+	for i := len(t.CleanupFuncs) - 1; i >= 0; i-- {
+		t.CleanupFuncs[i]()
+	}
+	t.CleanupFuncs = nil
+}
+
 func TestFatalTBCallsFatal(t *testing.T) {
 	mockT := newMockTB()
 	Fatal(mockT).Error("foo")
@@ -847,7 +868,7 @@ func TestSliceContains(t *testing.T) {
 				slice:  []int{1, 2, 3},
 				values: []int{4, 5},
 			},
-			expectedCalls: 2,
+			expectedCalls: 1,
 		},
 		{
 			name: "Slice contains and is missing",
@@ -856,7 +877,7 @@ func TestSliceContains(t *testing.T) {
 				slice:  []int{1, 2, 3},
 				values: []int{4, 3, 2, 0},
 			},
-			expectedCalls: 2,
+			expectedCalls: 1,
 		},
 	}
 	for _, tt := range tests {
@@ -870,8 +891,8 @@ func TestSliceContains(t *testing.T) {
 				t.Errorf("expected %d calls to Errorf(), got %d", tt.expectedCalls, n)
 			}
 
-			if n != tt.args.t.HelperCalls {
-				t.Errorf("expected %d calls to Helper(), got %d", tt.expectedCalls, tt.args.t.HelperCalls)
+			if tt.args.t.HelperCalls != 1 {
+				t.Errorf("expected 1 call to Helper(), got %d", tt.args.t.HelperCalls)
 			}
 		})
 	}
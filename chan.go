@@ -0,0 +1,173 @@
+package assert
+
+import (
+	"testing"
+	"time"
+)
+
+// Receives asserts that a value is received from a channel before the timeout
+// elapses, and returns it. The zero value is returned if nothing is received
+// or the channel is closed.
+func Receives[T any](t testing.TB, ch <-chan T, timeout time.Duration) T {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	var zero T
+	select {
+	case v, ok := <-ch:
+		if !ok {
+			t.Helper()
+			t.Errorf("expected to receive a value, but the channel is closed")
+			return zero
+		}
+		return v
+	case <-timer.C:
+		t.Helper()
+		t.Errorf("expected to receive a value within %s", timeout)
+		return zero
+	}
+}
+
+// ReceivesValue asserts that a value deeply equal to the expected value is
+// received from a channel before the timeout elapses.
+func ReceivesValue[T any](t testing.TB, ch <-chan T, expected T, timeout time.Duration) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case v, ok := <-ch:
+		if !ok {
+			t.Helper()
+			t.Errorf("expected to receive %s, but the channel is closed", formatValue(expected))
+			return
+		}
+		if diff := diffValues(expected, v); diff != "" {
+			t.Helper()
+			t.Errorf("received value is not equal:\n%s", indent(diff, "\t"))
+		}
+	case <-timer.C:
+		t.Helper()
+		t.Errorf("expected to receive %s within %s", formatValue(expected), timeout)
+	}
+}
+
+// NotReceives asserts that nothing is received from a channel for the entire
+// duration, and that the channel is not closed during it.
+func NotReceives[T any](t testing.TB, ch <-chan T, duration time.Duration) {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case v, ok := <-ch:
+		t.Helper()
+		if !ok {
+			t.Errorf("expected to receive nothing for %s, but the channel was closed", duration)
+		} else {
+			t.Errorf("expected to receive nothing for %s, received %s", duration, formatValue(v))
+		}
+	case <-timer.C:
+	}
+}
+
+// Closed asserts that a channel is closed before the timeout elapses. Values
+// that are still buffered or sent in the meantime fail the assertion, as the
+// channel has not been drained.
+func Closed[T any](t testing.TB, ch <-chan T, timeout time.Duration) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case v, ok := <-ch:
+		if ok {
+			t.Helper()
+			t.Errorf("expected channel to be closed, received %s", formatValue(v))
+		}
+	case <-timer.C:
+		t.Helper()
+		t.Errorf("expected channel to be closed within %s", timeout)
+	}
+}
+
+// NotClosed asserts that a channel is not closed. It does not block, and as a
+// channel can only be checked by receiving from it, a value that is ready to
+// be received is received and discarded.
+func NotClosed[T any](t testing.TB, ch <-chan T) {
+	select {
+	case _, ok := <-ch:
+		if !ok {
+			t.Helper()
+			t.Errorf("expected channel not to be closed")
+		}
+	default:
+	}
+}
+
+// ChannelDrainsTo asserts that the values received from a channel until it is
+// closed are deeply equal to the expected values, in order. The channel must be
+// closed before the timeout elapses. A nil slice of expected values is equal
+// to an empty one.
+func ChannelDrainsTo[T any](t testing.TB, ch <-chan T, expected []T, timeout time.Duration) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	// The received values are collected in a non-nil slice, so the values are
+	// compared element by element rather than by whether the slices are nil.
+	if expected == nil {
+		expected = []T{}
+	}
+
+	got := []T{}
+	for {
+		select {
+		case v, ok := <-ch:
+			if ok {
+				got = append(got, v)
+				continue
+			}
+
+			if diff := diffValues(expected, got); diff != "" {
+				t.Helper()
+				t.Errorf("drained values are not equal:\n%s", indent(diff, "\t"))
+			}
+			return
+		case <-timer.C:
+			t.Helper()
+			t.Errorf("expected channel to be closed within %s, received %s", timeout, formatValue(got))
+			return
+		}
+	}
+}
+
+// SendsWithin asserts that a value can be sent on a channel before the timeout
+// elapses, because the channel has a free buffer slot or a receiver is ready.
+// A send on a closed channel fails the assertion instead of panicking.
+func SendsWithin[T any](t testing.TB, ch chan<- T, v T, timeout time.Duration) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	sent, closed := trySend(ch, v, timer.C)
+	if closed {
+		t.Helper()
+		t.Errorf("expected to send %s, but the channel is closed", formatValue(v))
+	} else if !sent {
+		t.Helper()
+		t.Errorf("expected to send %s within %s", formatValue(v), timeout)
+	}
+}
+
+// trySend sends v on ch unless done receives first. Sending on a closed channel
+// is reported instead of panicking.
+func trySend[T any](ch chan<- T, v T, done <-chan time.Time) (sent, closed bool) {
+	defer func() {
+		if recover() != nil {
+			closed = true
+		}
+	}()
+
+	select {
+	case ch <- v:
+		return true, false
+	case <-done:
+		return false, false
+	}
+}
@@ -0,0 +1,400 @@
+package assert
+
+import (
+	"testing"
+	"time"
+)
+
+// chanTimeout is the time channel assertions wait for in tests that expect them
+// to time out.
+const chanTimeout = 10 * time.Millisecond
+
+// buffered returns an open channel holding the given values, with room for
+// one more.
+func buffered[T any](values ...T) chan T {
+	ch := make(chan T, len(values)+1)
+	for _, v := range values {
+		ch <- v
+	}
+	return ch
+}
+
+// closedWith returns a closed channel holding the given values.
+func closedWith[T any](values ...T) chan T {
+	ch := buffered(values...)
+	close(ch)
+	return ch
+}
+
+// sendLater returns an unbuffered channel on which the given values are sent
+// by another goroutine, which then closes it.
+func sendLater[T any](values ...T) chan T {
+	ch := make(chan T)
+	go func() {
+		defer close(ch)
+		for _, v := range values {
+			ch <- v
+		}
+	}()
+	return ch
+}
+
+// receiveLater returns an unbuffered channel from which another goroutine
+// receives a single value.
+func receiveLater[T any]() chan T {
+	ch := make(chan T)
+	go func() { <-ch }()
+	return ch
+}
+
+func TestReceives(t *testing.T) {
+	tests := []struct {
+		name            string
+		ch              chan int
+		timeout         time.Duration
+		expected        int
+		expectedMessage string
+		expectedCalls   int
+	}{
+		{name: "Receives value", ch: buffered(1), timeout: time.Second, expected: 1},
+		{name: "Value sent later", ch: sendLater(1), timeout: time.Second, expected: 1},
+		{
+			name:            "Timeout",
+			ch:              buffered[int](),
+			timeout:         chanTimeout,
+			expectedMessage: "expected to receive a value within 10ms",
+			expectedCalls:   1,
+		},
+		{
+			name:            "Closed",
+			ch:              closedWith[int](),
+			timeout:         chanTimeout,
+			expectedMessage: "expected to receive a value, but the channel is closed",
+			expectedCalls:   1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+
+			if got := Receives(mockT, tt.ch, tt.timeout); got != tt.expected {
+				t.Errorf("expected %d to be returned, got %d", tt.expected, got)
+			}
+			n := len(mockT.ErrorfCalls)
+
+			if n != tt.expectedCalls {
+				t.Fatalf("expected %d calls to Errorf(), got %d", tt.expectedCalls, n)
+			}
+
+			if n != mockT.HelperCalls {
+				t.Errorf("expected %d calls to Helper(), got %d", n, mockT.HelperCalls)
+			}
+
+			if n > 0 && mockT.ErrorfCalls[0].message() != tt.expectedMessage {
+				t.Errorf("expected message:\n%s\ngot:\n%s", tt.expectedMessage, mockT.ErrorfCalls[0].message())
+			}
+		})
+	}
+}
+
+func TestReceivesValue(t *testing.T) {
+	tests := []struct {
+		name            string
+		ch              chan []int
+		expected        []int
+		timeout         time.Duration
+		expectedMessage string
+		expectedCalls   int
+	}{
+		{name: "Receives expected value", ch: buffered([]int{1, 2}), expected: []int{1, 2}, timeout: time.Second},
+		{
+			name:            "Receives different value",
+			ch:              buffered([]int{1, 3}),
+			expected:        []int{1, 2},
+			timeout:         time.Second,
+			expectedMessage: "received value is not equal:\n\t[1]: expected 2, got 3",
+			expectedCalls:   1,
+		},
+		{
+			name:            "Timeout",
+			ch:              buffered[[]int](),
+			expected:        []int{1},
+			timeout:         chanTimeout,
+			expectedMessage: "expected to receive []int{1} within 10ms",
+			expectedCalls:   1,
+		},
+		{
+			name:            "Closed",
+			ch:              closedWith[[]int](),
+			expected:        []int{1},
+			timeout:         chanTimeout,
+			expectedMessage: "expected to receive []int{1}, but the channel is closed",
+			expectedCalls:   1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+
+			ReceivesValue(mockT, tt.ch, tt.expected, tt.timeout)
+			n := len(mockT.ErrorfCalls)
+
+			if n != tt.expectedCalls {
+				t.Fatalf("expected %d calls to Errorf(), got %d", tt.expectedCalls, n)
+			}
+
+			if n != mockT.HelperCalls {
+				t.Errorf("expected %d calls to Helper(), got %d", n, mockT.HelperCalls)
+			}
+
+			if n > 0 && mockT.ErrorfCalls[0].message() != tt.expectedMessage {
+				t.Errorf("expected message:\n%s\ngot:\n%s", tt.expectedMessage, mockT.ErrorfCalls[0].message())
+			}
+		})
+	}
+}
+
+func TestNotReceives(t *testing.T) {
+	tests := []struct {
+		name            string
+		ch              chan int
+		expectedMessage string
+		expectedCalls   int
+	}{
+		{name: "Nothing received", ch: buffered[int]()},
+		{
+			name:            "Value received",
+			ch:              buffered(1),
+			expectedMessage: "expected to receive nothing for 10ms, received 1",
+			expectedCalls:   1,
+		},
+		{
+			name:            "Closed",
+			ch:              closedWith[int](),
+			expectedMessage: "expected to receive nothing for 10ms, but the channel was closed",
+			expectedCalls:   1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+
+			NotReceives(mockT, tt.ch, chanTimeout)
+			n := len(mockT.ErrorfCalls)
+
+			if n != tt.expectedCalls {
+				t.Fatalf("expected %d calls to Errorf(), got %d", tt.expectedCalls, n)
+			}
+
+			if n != mockT.HelperCalls {
+				t.Errorf("expected %d calls to Helper(), got %d", n, mockT.HelperCalls)
+			}
+
+			if n > 0 && mockT.ErrorfCalls[0].message() != tt.expectedMessage {
+				t.Errorf("expected message:\n%s\ngot:\n%s", tt.expectedMessage, mockT.ErrorfCalls[0].message())
+			}
+		})
+	}
+}
+
+func TestClosed(t *testing.T) {
+	tests := []struct {
+		name            string
+		ch              chan int
+		timeout         time.Duration
+		expectedMessage string
+		expectedCalls   int
+	}{
+		{name: "Closed", ch: closedWith[int](), timeout: time.Second},
+		{name: "Closed later", ch: sendLater[int](), timeout: time.Second},
+		{
+			name:            "Buffered value",
+			ch:              closedWith(1),
+			timeout:         chanTimeout,
+			expectedMessage: "expected channel to be closed, received 1",
+			expectedCalls:   1,
+		},
+		{
+			name:            "Timeout",
+			ch:              buffered[int](),
+			timeout:         chanTimeout,
+			expectedMessage: "expected channel to be closed within 10ms",
+			expectedCalls:   1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+
+			Closed(mockT, tt.ch, tt.timeout)
+			n := len(mockT.ErrorfCalls)
+
+			if n != tt.expectedCalls {
+				t.Fatalf("expected %d calls to Errorf(), got %d", tt.expectedCalls, n)
+			}
+
+			if n != mockT.HelperCalls {
+				t.Errorf("expected %d calls to Helper(), got %d", n, mockT.HelperCalls)
+			}
+
+			if n > 0 && mockT.ErrorfCalls[0].message() != tt.expectedMessage {
+				t.Errorf("expected message:\n%s\ngot:\n%s", tt.expectedMessage, mockT.ErrorfCalls[0].message())
+			}
+		})
+	}
+}
+
+func TestNotClosed(t *testing.T) {
+	tests := []struct {
+		name            string
+		ch              chan int
+		expectedMessage string
+		expectedCalls   int
+	}{
+		{name: "Empty channel", ch: buffered[int]()},
+		{name: "Buffered value", ch: buffered(1)},
+		{
+			name:            "Closed",
+			ch:              closedWith[int](),
+			expectedMessage: "expected channel not to be closed",
+			expectedCalls:   1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+
+			NotClosed(mockT, tt.ch)
+			n := len(mockT.ErrorfCalls)
+
+			if n != tt.expectedCalls {
+				t.Fatalf("expected %d calls to Errorf(), got %d", tt.expectedCalls, n)
+			}
+
+			if n != mockT.HelperCalls {
+				t.Errorf("expected %d calls to Helper(), got %d", n, mockT.HelperCalls)
+			}
+
+			if n > 0 && mockT.ErrorfCalls[0].message() != tt.expectedMessage {
+				t.Errorf("expected message:\n%s\ngot:\n%s", tt.expectedMessage, mockT.ErrorfCalls[0].message())
+			}
+		})
+	}
+}
+
+func TestChannelDrainsTo(t *testing.T) {
+	tests := []struct {
+		name            string
+		ch              chan int
+		expected        []int
+		timeout         time.Duration
+		expectedMessage string
+		expectedCalls   int
+	}{
+		{name: "Drains to expected values", ch: closedWith(1, 2, 3), expected: []int{1, 2, 3}, timeout: time.Second},
+		{name: "Values sent later", ch: sendLater(1, 2), expected: []int{1, 2}, timeout: time.Second},
+		{name: "Empty", ch: closedWith[int](), expected: []int{}, timeout: time.Second},
+		{name: "Nil expected values", ch: closedWith[int](), expected: nil, timeout: time.Second},
+		{
+			name:            "Unexpected values",
+			ch:              closedWith(1),
+			expected:        nil,
+			timeout:         time.Second,
+			expectedMessage: "drained values are not equal:\n\t[0]: unexpected element 1",
+			expectedCalls:   1,
+		},
+		{
+			name:            "Different values",
+			ch:              closedWith(1, 3),
+			expected:        []int{1, 2},
+			timeout:         time.Second,
+			expectedMessage: "drained values are not equal:\n\t[1]: expected 2, got 3",
+			expectedCalls:   1,
+		},
+		{
+			name:            "Not closed",
+			ch:              buffered(1),
+			expected:        []int{1},
+			timeout:         chanTimeout,
+			expectedMessage: "expected channel to be closed within 10ms, received []int{1}",
+			expectedCalls:   1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+
+			ChannelDrainsTo(mockT, tt.ch, tt.expected, tt.timeout)
+			n := len(mockT.ErrorfCalls)
+
+			if n != tt.expectedCalls {
+				t.Fatalf("expected %d calls to Errorf(), got %d", tt.expectedCalls, n)
+			}
+
+			if n != mockT.HelperCalls {
+				t.Errorf("expected %d calls to Helper(), got %d", n, mockT.HelperCalls)
+			}
+
+			if n > 0 && mockT.ErrorfCalls[0].message() != tt.expectedMessage {
+				t.Errorf("expected message:\n%s\ngot:\n%s", tt.expectedMessage, mockT.ErrorfCalls[0].message())
+			}
+		})
+	}
+}
+
+func TestSendsWithin(t *testing.T) {
+	full := make(chan int, 1)
+	full <- 0
+
+	tests := []struct {
+		name            string
+		ch              chan int
+		timeout         time.Duration
+		expectedMessage string
+		expectedCalls   int
+	}{
+		{name: "Buffered channel with room", ch: buffered[int](), timeout: time.Second},
+		{name: "Unbuffered channel with receiver", ch: receiveLater[int](), timeout: time.Second},
+		{
+			name:            "Unbuffered channel without receiver",
+			ch:              make(chan int),
+			timeout:         chanTimeout,
+			expectedMessage: "expected to send 1 within 10ms",
+			expectedCalls:   1,
+		},
+		{
+			name:            "Full buffered channel",
+			ch:              full,
+			timeout:         chanTimeout,
+			expectedMessage: "expected to send 1 within 10ms",
+			expectedCalls:   1,
+		},
+		{
+			name:            "Closed",
+			ch:              closedWith[int](),
+			timeout:         chanTimeout,
+			expectedMessage: "expected to send 1, but the channel is closed",
+			expectedCalls:   1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+
+			SendsWithin(mockT, tt.ch, 1, tt.timeout)
+			n := len(mockT.ErrorfCalls)
+
+			if n != tt.expectedCalls {
+				t.Fatalf("expected %d calls to Errorf(), got %d", tt.expectedCalls, n)
+			}
+
+			if n != mockT.HelperCalls {
+				t.Errorf("expected %d calls to Helper(), got %d", n, mockT.HelperCalls)
+			}
+
+			if n > 0 && mockT.ErrorfCalls[0].message() != tt.expectedMessage {
+				t.Errorf("expected message:\n%s\ngot:\n%s", tt.expectedMessage, mockT.ErrorfCalls[0].message())
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+// Package clock provides a Clock interface for code that depends on the passage
+// of time, with a real implementation backed by the time package and a
+// FakeClock whose time only changes when a test advances it.
+package clock
+
+import "time"
+
+// Clock tells the time and creates timers. Code that accepts a Clock instead
+// of calling the time package directly can be tested deterministically with a
+// FakeClock.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// Since returns the time elapsed since t.
+	Since(t time.Time) time.Duration
+	// Until returns the duration until t.
+	Until(t time.Time) time.Duration
+	// Sleep pauses the calling goroutine for at least the duration d.
+	Sleep(d time.Duration)
+	// After waits for the duration to elapse and then sends the current time
+	// on the returned channel.
+	After(d time.Duration) <-chan time.Time
+	// NewTimer creates a Timer that sends the current time on its channel
+	// after at least duration d.
+	NewTimer(d time.Duration) *Timer
+	// AfterFunc waits for the duration to elapse and then calls f. The
+	// returned Timer can be used to cancel the call.
+	AfterFunc(d time.Duration, f func()) *Timer
+	// NewTicker returns a Ticker that sends the current time on its channel
+	// every period d.
+	NewTicker(d time.Duration) *Ticker
+}
+
+// Timer is a single event created by a Clock, in the same way as time.Timer.
+type Timer struct {
+	// C receives the time when the timer fires. It is nil for timers created
+	// by AfterFunc.
+	C <-chan time.Time
+
+	real *time.Timer
+	fake *fakeTimer
+}
+
+// Stop prevents the timer from firing. It reports whether the call stopped the
+// timer, and is false if the timer has already fired or been stopped.
+func (t *Timer) Stop() bool {
+	if t.fake != nil {
+		return t.fake.stop()
+	}
+	return t.real.Stop()
+}
+
+// Reset changes the timer to fire after duration d. It reports whether the
+// timer was active.
+func (t *Timer) Reset(d time.Duration) bool {
+	if t.fake != nil {
+		return t.fake.reset(d, 0)
+	}
+	return t.real.Reset(d)
+}
+
+// Ticker delivers ticks at intervals, in the same way as time.Ticker.
+type Ticker struct {
+	// C receives the time of each tick.
+	C <-chan time.Time
+
+	real *time.Ticker
+	fake *fakeTimer
+}
+
+// Stop turns off the ticker. No more ticks will be sent.
+func (t *Ticker) Stop() {
+	if t.fake != nil {
+		t.fake.stop()
+		return
+	}
+	t.real.Stop()
+}
+
+// Reset stops the ticker and resets its period to d. The next tick arrives
+// after the new period elapses.
+func (t *Ticker) Reset(d time.Duration) {
+	if d <= 0 {
+		panic("clock: non-positive interval for Ticker.Reset")
+	}
+
+	if t.fake != nil {
+		t.fake.reset(d, d)
+		return
+	}
+	t.real.Reset(d)
+}
+
+// Real returns a Clock backed by the time package.
+func Real() Clock {
+	return realClock{}
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) Since(t time.Time) time.Duration        { return time.Since(t) }
+func (realClock) Until(t time.Time) time.Duration        { return time.Until(t) }
+func (realClock) Sleep(d time.Duration)                  { time.Sleep(d) }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+func (realClock) NewTimer(d time.Duration) *Timer {
+	t := time.NewTimer(d)
+	return &Timer{C: t.C, real: t}
+}
+
+func (realClock) AfterFunc(d time.Duration, f func()) *Timer {
+	return &Timer{real: time.AfterFunc(d, f)}
+}
+
+func (realClock) NewTicker(d time.Duration) *Ticker {
+	t := time.NewTicker(d)
+	return &Ticker{C: t.C, real: t}
+}
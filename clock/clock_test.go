@@ -0,0 +1,31 @@
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRealClock(t *testing.T) {
+	c := Real()
+
+	start := c.Now()
+	c.Sleep(time.Millisecond)
+	if d := c.Since(start); d < time.Millisecond {
+		t.Errorf("expected at least 1ms to elapse, got %s", d)
+	}
+
+	<-c.After(time.Millisecond)
+	<-c.NewTimer(time.Millisecond).C
+
+	ticker := c.NewTicker(time.Millisecond)
+	<-ticker.C
+	ticker.Stop()
+
+	called := make(chan struct{})
+	c.AfterFunc(time.Millisecond, func() { close(called) })
+	<-called
+
+	if !c.NewTimer(time.Hour).Stop() {
+		t.Errorf("expected Stop to report an active timer")
+	}
+}
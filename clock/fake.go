@@ -0,0 +1,246 @@
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// FakeClock is a Clock whose time only changes when Advance or Set is called.
+//
+// Timers, tickers, and functions scheduled with AfterFunc fire while the clock
+// is moved forward, in order of their deadlines. Events sharing a deadline
+// fire in the order they were scheduled. The clock is set to an event's
+// deadline before it fires, so Now reports the deadline to the code reacting
+// to it, and functions scheduled with AfterFunc are called on the goroutine
+// moving the clock. Events with a deadline that has already passed when they
+// are scheduled fire immediately.
+//
+// A FakeClock is safe for concurrent use.
+type FakeClock struct {
+	mu   sync.Mutex
+	cond *sync.Cond
+	now  time.Time
+	seq  uint64
+	// timers holds the scheduled events that have not fired or been
+	// stopped.
+	timers []*fakeTimer
+}
+
+// NewFake returns a FakeClock set to now.
+func NewFake(now time.Time) *FakeClock {
+	c := &FakeClock{now: now}
+	c.cond = sync.NewCond(&c.mu)
+	return c
+}
+
+// Now returns the clock's current time.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+// Since returns the time elapsed on the clock since t.
+func (c *FakeClock) Since(t time.Time) time.Duration {
+	return c.Now().Sub(t)
+}
+
+// Until returns the duration on the clock until t.
+func (c *FakeClock) Until(t time.Time) time.Duration {
+	return t.Sub(c.Now())
+}
+
+// Sleep blocks until the clock has been advanced by at least d.
+func (c *FakeClock) Sleep(d time.Duration) {
+	if d <= 0 {
+		return
+	}
+	<-c.After(d)
+}
+
+// After returns a channel that receives the clock's time once it has been
+// advanced by d.
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	return c.NewTimer(d).C
+}
+
+// NewTimer returns a Timer that fires once the clock has been advanced by d.
+func (c *FakeClock) NewTimer(d time.Duration) *Timer {
+	t := &fakeTimer{clock: c, c: make(chan time.Time, 1)}
+	t.reset(d, 0)
+	return &Timer{C: t.c, fake: t}
+}
+
+// AfterFunc calls f once the clock has been advanced by d.
+func (c *FakeClock) AfterFunc(d time.Duration, f func()) *Timer {
+	t := &fakeTimer{clock: c, fn: f}
+	t.reset(d, 0)
+	return &Timer{fake: t}
+}
+
+// NewTicker returns a Ticker that ticks every time the clock is advanced by
+// another period d. As with time.Ticker, ticks are dropped if the receiver
+// falls behind. It panics if d is not positive.
+func (c *FakeClock) NewTicker(d time.Duration) *Ticker {
+	if d <= 0 {
+		panic("clock: non-positive interval for NewTicker")
+	}
+
+	t := &fakeTimer{clock: c, c: make(chan time.Time, 1)}
+	t.reset(d, d)
+	return &Ticker{C: t.c, fake: t}
+}
+
+// Advance moves the clock forward by d, firing the events that are due in
+// order.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.Set(c.Now().Add(d))
+}
+
+// Set sets the clock to t. If t is after the clock's current time, the events
+// that are due are fired in order. Setting the clock back fires no events.
+func (c *FakeClock) Set(t time.Time) {
+	for c.fireNext(t) {
+	}
+
+	c.mu.Lock()
+	c.now = t
+	c.mu.Unlock()
+}
+
+// BlockUntil blocks until at least n timers, tickers, or functions scheduled
+// with AfterFunc are waiting to fire. It allows a test to wait for a goroutine
+// to start sleeping or waiting on a timer before advancing the clock.
+func (c *FakeClock) BlockUntil(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for len(c.timers) < n {
+		c.cond.Wait()
+	}
+}
+
+// fireNext fires the earliest event due at or before end, reporting whether
+// there was one.
+func (c *FakeClock) fireNext(end time.Time) bool {
+	c.mu.Lock()
+
+	next := -1
+	for i, t := range c.timers {
+		if t.when.After(end) {
+			continue
+		}
+		if next < 0 || t.when.Before(c.timers[next].when) ||
+			t.when.Equal(c.timers[next].when) && t.seq < c.timers[next].seq {
+			next = i
+		}
+	}
+
+	if next < 0 {
+		c.mu.Unlock()
+		return false
+	}
+
+	t := c.timers[next]
+	if t.when.After(c.now) {
+		c.now = t.when
+	}
+	now := c.now
+
+	if t.period > 0 {
+		c.seq++
+		t.when = t.when.Add(t.period)
+		t.seq = c.seq
+	} else {
+		c.remove(next)
+	}
+	c.mu.Unlock()
+
+	t.fire(now)
+	return true
+}
+
+// remove unschedules the event at index i of c.timers.
+func (c *FakeClock) remove(i int) {
+	c.timers[i].active = false
+	c.timers = append(c.timers[:i], c.timers[i+1:]...)
+}
+
+// fireDue fires the events whose deadlines have already passed.
+func (c *FakeClock) fireDue() {
+	now := c.Now()
+	for c.fireNext(now) {
+	}
+}
+
+// fakeTimer is an event scheduled on a FakeClock. It backs a Timer, a Ticker,
+// or a function scheduled with AfterFunc.
+type fakeTimer struct {
+	clock *FakeClock
+	c     chan time.Time
+	fn    func()
+
+	// The remaining fields are guarded by clock.mu.
+	when   time.Time
+	period time.Duration
+	seq    uint64
+	active bool
+}
+
+func (t *fakeTimer) fire(now time.Time) {
+	if t.fn != nil {
+		t.fn()
+		return
+	}
+
+	select {
+	case t.c <- now:
+	default:
+	}
+}
+
+// reset schedules the event to fire after d, repeating every period if it is
+// positive. It reports whether the event was scheduled before.
+func (t *fakeTimer) reset(d, period time.Duration) bool {
+	c := t.clock
+	c.mu.Lock()
+
+	active := t.active
+	if !active {
+		c.timers = append(c.timers, t)
+		t.active = true
+	}
+
+	c.seq++
+	t.when = c.now.Add(d)
+	t.period = period
+	t.seq = c.seq
+
+	c.cond.Broadcast()
+	c.mu.Unlock()
+
+	if d <= 0 {
+		c.fireDue()
+	}
+	return active
+}
+
+// stop unschedules the event, reporting whether it was scheduled.
+func (t *fakeTimer) stop() bool {
+	c := t.clock
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !t.active {
+		return false
+	}
+
+	for i, s := range c.timers {
+		if s == t {
+			c.remove(i)
+			break
+		}
+	}
+	return true
+}
@@ -0,0 +1,183 @@
+package clock
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+var epoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestFakeClockFiringOrder(t *testing.T) {
+	c := NewFake(epoch)
+
+	var (
+		mu     sync.Mutex
+		events []string
+	)
+	record := func(name string) func() {
+		return func() {
+			mu.Lock()
+			defer mu.Unlock()
+			events = append(events, name+"@"+c.Since(epoch).String())
+		}
+	}
+
+	c.AfterFunc(3*time.Second, record("c"))
+	c.AfterFunc(time.Second, record("a"))
+	c.AfterFunc(2*time.Second, record("b1"))
+	c.AfterFunc(2*time.Second, record("b2"))
+	stopped := c.AfterFunc(2*time.Second, record("stopped"))
+	c.AfterFunc(10*time.Second, record("late"))
+
+	if !stopped.Stop() {
+		t.Errorf("expected Stop to report an active timer")
+	}
+	if stopped.Stop() {
+		t.Errorf("expected second Stop to report an inactive timer")
+	}
+
+	c.Advance(5 * time.Second)
+
+	expected := []string{"a@1s", "b1@2s", "b2@2s", "c@3s"}
+	if !reflect.DeepEqual(events, expected) {
+		t.Errorf("expected events %q, got %q", expected, events)
+	}
+
+	if got := c.Since(epoch); got != 5*time.Second {
+		t.Errorf("expected clock to be advanced by 5s, got %s", got)
+	}
+}
+
+func TestFakeClockNestedAfterFunc(t *testing.T) {
+	c := NewFake(epoch)
+
+	var fired []time.Duration
+	var tick func()
+	tick = func() {
+		fired = append(fired, c.Since(epoch))
+		if len(fired) < 3 {
+			c.AfterFunc(time.Second, tick)
+		}
+	}
+	c.AfterFunc(time.Second, tick)
+
+	c.Advance(time.Minute)
+
+	expected := []time.Duration{time.Second, 2 * time.Second, 3 * time.Second}
+	if !reflect.DeepEqual(fired, expected) {
+		t.Errorf("expected calls at %v, got %v", expected, fired)
+	}
+}
+
+func TestFakeClockTimer(t *testing.T) {
+	c := NewFake(epoch)
+	timer := c.NewTimer(time.Minute)
+
+	c.Advance(59 * time.Second)
+	select {
+	case <-timer.C:
+		t.Fatal("timer fired early")
+	default:
+	}
+
+	if !timer.Reset(time.Minute) {
+		t.Errorf("expected Reset to report an active timer")
+	}
+
+	c.Advance(time.Minute)
+	select {
+	case now := <-timer.C:
+		if expected := epoch.Add(119 * time.Second); !now.Equal(expected) {
+			t.Errorf("expected timer to fire at %s, got %s", expected, now)
+		}
+	default:
+		t.Fatal("timer did not fire")
+	}
+
+	if timer.Stop() {
+		t.Errorf("expected Stop to report a fired timer as inactive")
+	}
+
+	select {
+	case <-c.After(0):
+	default:
+		t.Fatal("expected timer with zero duration to fire immediately")
+	}
+}
+
+func TestFakeClockTicker(t *testing.T) {
+	c := NewFake(epoch)
+	ticker := c.NewTicker(time.Second)
+
+	c.Advance(time.Second)
+	if now := <-ticker.C; !now.Equal(epoch.Add(time.Second)) {
+		t.Errorf("expected tick at 1s, got %s", now.Sub(epoch))
+	}
+
+	// Ticks are dropped while the channel is full.
+	c.Advance(5 * time.Second)
+	if now := <-ticker.C; !now.Equal(epoch.Add(2 * time.Second)) {
+		t.Errorf("expected tick at 2s, got %s", now.Sub(epoch))
+	}
+	select {
+	case now := <-ticker.C:
+		t.Fatalf("unexpected tick at %s", now.Sub(epoch))
+	default:
+	}
+
+	ticker.Reset(time.Minute)
+	c.Advance(59 * time.Second)
+	select {
+	case now := <-ticker.C:
+		t.Fatalf("unexpected tick at %s", now.Sub(epoch))
+	default:
+	}
+
+	ticker.Stop()
+	c.Advance(time.Hour)
+	select {
+	case now := <-ticker.C:
+		t.Fatalf("unexpected tick after Stop at %s", now.Sub(epoch))
+	default:
+	}
+}
+
+func TestFakeClockSleep(t *testing.T) {
+	c := NewFake(epoch)
+
+	done := make(chan time.Time)
+	go func() {
+		c.Sleep(time.Hour)
+		done <- c.Now()
+	}()
+
+	c.BlockUntil(1)
+	c.Advance(time.Hour)
+
+	if now := <-done; !now.Equal(epoch.Add(time.Hour)) {
+		t.Errorf("expected sleep to end at %s, got %s", epoch.Add(time.Hour), now)
+	}
+}
+
+func TestFakeClockSet(t *testing.T) {
+	c := NewFake(epoch)
+	timer := c.NewTimer(time.Hour)
+
+	c.Set(epoch.Add(-time.Hour))
+	if now := c.Now(); !now.Equal(epoch.Add(-time.Hour)) {
+		t.Errorf("expected clock to be set back, got %s", now)
+	}
+
+	c.Set(epoch.Add(2 * time.Hour))
+	select {
+	case <-timer.C:
+	default:
+		t.Fatal("timer did not fire")
+	}
+
+	if got := c.Until(epoch.Add(3 * time.Hour)); got != time.Hour {
+		t.Errorf("expected 1h until deadline, got %s", got)
+	}
+}
@@ -0,0 +1,105 @@
+package assert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// ContextDone asserts that a context is done before the duration elapses.
+func ContextDone(t testing.TB, ctx context.Context, within time.Duration) {
+	timer := time.NewTimer(within)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+		t.Helper()
+		t.Errorf("expected context to be done within %s", within)
+	}
+}
+
+// ContextNotDone asserts that a context is not done. It does not block.
+func ContextNotDone(t testing.TB, ctx context.Context) {
+	select {
+	case <-ctx.Done():
+		t.Helper()
+		t.Errorf("expected context not to be done, got %s", contextErr(ctx))
+	default:
+	}
+}
+
+// ContextHasDeadline asserts that a context has a deadline at most tolerance
+// before or after an expected time.
+func ContextHasDeadline(t testing.TB, ctx context.Context, expected time.Time, tolerance time.Duration) {
+	if tolerance < 0 {
+		t.Helper()
+		t.Fatalf("invalid tolerance %s", tolerance)
+		return
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		t.Helper()
+		t.Errorf("expected context to have a deadline within %s of %s, but it has none", tolerance, formatTime(expected))
+		return
+	}
+
+	if d := deadline.Sub(expected); d < -tolerance || d > tolerance {
+		t.Helper()
+		t.Errorf("expected context deadline %s to be within %s of %s, delta %s", formatTime(deadline), tolerance, formatTime(expected), formatDelta(d))
+	}
+}
+
+// ContextErrIs asserts that a context is done and that either its error or its
+// cause, as returned by context.Cause, is the target error, as reported by
+// errors.Is. The target is usually context.Canceled, context.DeadlineExceeded,
+// or the cause given to a context.CancelCauseFunc.
+func ContextErrIs(t testing.TB, ctx context.Context, target error) {
+	err := ctx.Err()
+	if err == nil {
+		t.Helper()
+		t.Errorf(`expected context error "%v", but the context is not done`, target)
+		return
+	}
+
+	if !errors.Is(err, target) && !errors.Is(context.Cause(ctx), target) {
+		t.Helper()
+		t.Errorf(`expected context error "%v", got %s`, target, contextErr(ctx))
+	}
+}
+
+// ContextValue asserts that a context carries a value for a key that is deeply
+// equal to the expected value.
+func ContextValue[K comparable, V any](t testing.TB, ctx context.Context, key K, expected V) {
+	v := ctx.Value(key)
+	if v == nil {
+		t.Helper()
+		t.Errorf("expected context value for key %s, got none", formatValue(key))
+		return
+	}
+
+	got, ok := v.(V)
+	if !ok {
+		t.Helper()
+		t.Errorf("expected context value for key %s of type %T, got %T", formatValue(key), expected, v)
+		return
+	}
+
+	if diff := diffValues(expected, got); diff != "" {
+		t.Helper()
+		t.Errorf("context value for key %s is not equal:\n%s", formatValue(key), indent(diff, "\t"))
+	}
+}
+
+// contextErr describes the error of a done context, along with its cause if
+// it differs from the error.
+func contextErr(ctx context.Context) string {
+	err := ctx.Err()
+	if cause := context.Cause(ctx); cause != nil && cause != err {
+		return fmt.Sprintf(`"%v" with cause "%v"`, err, cause)
+	}
+	return fmt.Sprintf(`"%v"`, err)
+}
@@ -0,0 +1,290 @@
+package assert
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type contextKey string
+
+func canceledContext(cause error) context.Context {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	cancel(cause)
+	return ctx
+}
+
+func TestContextDone(t *testing.T) {
+	expired, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+
+	tests := []struct {
+		name            string
+		ctx             context.Context
+		within          time.Duration
+		expectedMessage string
+		expectedCalls   int
+	}{
+		{name: "Canceled", ctx: canceledContext(nil), within: time.Second},
+		{name: "Deadline exceeded", ctx: expired, within: time.Second},
+		{
+			name:            "Not done",
+			ctx:             context.Background(),
+			within:          10 * time.Millisecond,
+			expectedMessage: "expected context to be done within 10ms",
+			expectedCalls:   1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+
+			ContextDone(mockT, tt.ctx, tt.within)
+			n := len(mockT.ErrorfCalls)
+
+			if n != tt.expectedCalls {
+				t.Fatalf("expected %d calls to Errorf(), got %d", tt.expectedCalls, n)
+			}
+
+			if n != mockT.HelperCalls {
+				t.Errorf("expected %d calls to Helper(), got %d", n, mockT.HelperCalls)
+			}
+
+			if n > 0 && mockT.ErrorfCalls[0].message() != tt.expectedMessage {
+				t.Errorf("expected message:\n%s\ngot:\n%s", tt.expectedMessage, mockT.ErrorfCalls[0].message())
+			}
+		})
+	}
+}
+
+func TestContextNotDone(t *testing.T) {
+	tests := []struct {
+		name            string
+		ctx             context.Context
+		expectedMessage string
+		expectedCalls   int
+	}{
+		{name: "Not done", ctx: context.Background()},
+		{
+			name:            "Canceled",
+			ctx:             canceledContext(nil),
+			expectedMessage: `expected context not to be done, got "context canceled"`,
+			expectedCalls:   1,
+		},
+		{
+			name:            "Canceled with cause",
+			ctx:             canceledContext(errors.New("shutdown")),
+			expectedMessage: `expected context not to be done, got "context canceled" with cause "shutdown"`,
+			expectedCalls:   1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+
+			ContextNotDone(mockT, tt.ctx)
+			n := len(mockT.ErrorfCalls)
+
+			if n != tt.expectedCalls {
+				t.Fatalf("expected %d calls to Errorf(), got %d", tt.expectedCalls, n)
+			}
+
+			if n != mockT.HelperCalls {
+				t.Errorf("expected %d calls to Helper(), got %d", n, mockT.HelperCalls)
+			}
+
+			if n > 0 && mockT.ErrorfCalls[0].message() != tt.expectedMessage {
+				t.Errorf("expected message:\n%s\ngot:\n%s", tt.expectedMessage, mockT.ErrorfCalls[0].message())
+			}
+		})
+	}
+}
+
+func TestContextHasDeadline(t *testing.T) {
+	ctx, cancel := context.WithDeadline(context.Background(), testTime)
+	defer cancel()
+
+	tests := []struct {
+		name               string
+		ctx                context.Context
+		expected           time.Time
+		tolerance          time.Duration
+		expectedMessage    string
+		expectedErrorCalls int
+		expectedFatalCalls int
+	}{
+		{name: "Within tolerance", ctx: ctx, expected: testTime.Add(time.Second), tolerance: time.Second},
+		{
+			name:               "Outside tolerance",
+			ctx:                ctx,
+			expected:           testTime.Add(time.Hour),
+			tolerance:          time.Minute,
+			expectedMessage:    "expected context deadline 2024-03-09T23:30:00.0000005Z to be within 1m0s of 2024-03-10T00:30:00.0000005Z, delta -1h0m0s",
+			expectedErrorCalls: 1,
+		},
+		{
+			name:               "No deadline",
+			ctx:                context.Background(),
+			expected:           testTime,
+			tolerance:          time.Minute,
+			expectedMessage:    "expected context to have a deadline within 1m0s of 2024-03-09T23:30:00.0000005Z, but it has none",
+			expectedErrorCalls: 1,
+		},
+		{
+			name:               "Negative tolerance",
+			ctx:                ctx,
+			expected:           testTime,
+			tolerance:          -time.Second,
+			expectedMessage:    "invalid tolerance -1s",
+			expectedFatalCalls: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+
+			ContextHasDeadline(mockT, tt.ctx, tt.expected, tt.tolerance)
+			n := len(mockT.ErrorfCalls)
+			m := len(mockT.FatalfCalls)
+
+			if n != tt.expectedErrorCalls {
+				t.Fatalf("expected %d calls to Errorf(), got %d", tt.expectedErrorCalls, n)
+			}
+
+			if m != tt.expectedFatalCalls {
+				t.Fatalf("expected %d calls to Fatalf(), got %d", tt.expectedFatalCalls, m)
+			}
+
+			if mockT.HelperCalls != n+m {
+				t.Errorf("expected %d calls to Helper(), got %d", n+m, mockT.HelperCalls)
+			}
+
+			if n > 0 && mockT.ErrorfCalls[0].message() != tt.expectedMessage {
+				t.Errorf("expected message:\n%s\ngot:\n%s", tt.expectedMessage, mockT.ErrorfCalls[0].message())
+			}
+
+			if m > 0 && mockT.FatalfCalls[0].message() != tt.expectedMessage {
+				t.Errorf("expected message:\n%s\ngot:\n%s", tt.expectedMessage, mockT.FatalfCalls[0].message())
+			}
+		})
+	}
+}
+
+func TestContextErrIs(t *testing.T) {
+	errShutdown := errors.New("shutdown")
+
+	tests := []struct {
+		name            string
+		ctx             context.Context
+		target          error
+		expectedMessage string
+		expectedCalls   int
+	}{
+		{name: "Canceled", ctx: canceledContext(nil), target: context.Canceled},
+		{name: "Cause", ctx: canceledContext(errShutdown), target: errShutdown},
+		{name: "Canceled with cause", ctx: canceledContext(errShutdown), target: context.Canceled},
+		{
+			name:            "Different error",
+			ctx:             canceledContext(errShutdown),
+			target:          context.DeadlineExceeded,
+			expectedMessage: `expected context error "context deadline exceeded", got "context canceled" with cause "shutdown"`,
+			expectedCalls:   1,
+		},
+		{
+			name:            "Not done",
+			ctx:             context.Background(),
+			target:          context.Canceled,
+			expectedMessage: `expected context error "context canceled", but the context is not done`,
+			expectedCalls:   1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+
+			ContextErrIs(mockT, tt.ctx, tt.target)
+			n := len(mockT.ErrorfCalls)
+
+			if n != tt.expectedCalls {
+				t.Fatalf("expected %d calls to Errorf(), got %d", tt.expectedCalls, n)
+			}
+
+			if n != mockT.HelperCalls {
+				t.Errorf("expected %d calls to Helper(), got %d", n, mockT.HelperCalls)
+			}
+
+			if n > 0 && mockT.ErrorfCalls[0].message() != tt.expectedMessage {
+				t.Errorf("expected message:\n%s\ngot:\n%s", tt.expectedMessage, mockT.ErrorfCalls[0].message())
+			}
+		})
+	}
+}
+
+func TestContextValue(t *testing.T) {
+	ctx := context.WithValue(context.Background(), contextKey("roles"), []string{"admin", "editor"})
+
+	tests := []struct {
+		name            string
+		key             any
+		expected        []string
+		expectedMessage string
+		expectedCalls   int
+	}{
+		{name: "Equal value", key: contextKey("roles"), expected: []string{"admin", "editor"}},
+		{
+			name:            "Different value",
+			key:             contextKey("roles"),
+			expected:        []string{"admin", "viewer"},
+			expectedMessage: "context value for key \"roles\" is not equal:\n\t[1]: expected \"viewer\", got \"editor\"",
+			expectedCalls:   1,
+		},
+		{
+			name:            "Missing key",
+			key:             "roles",
+			expected:        []string{"admin"},
+			expectedMessage: `expected context value for key "roles", got none`,
+			expectedCalls:   1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+
+			ContextValue(mockT, ctx, tt.key, tt.expected)
+			n := len(mockT.ErrorfCalls)
+
+			if n != tt.expectedCalls {
+				t.Fatalf("expected %d calls to Errorf(), got %d", tt.expectedCalls, n)
+			}
+
+			if n != mockT.HelperCalls {
+				t.Errorf("expected %d calls to Helper(), got %d", n, mockT.HelperCalls)
+			}
+
+			if n > 0 && mockT.ErrorfCalls[0].message() != tt.expectedMessage {
+				t.Errorf("expected message:\n%s\ngot:\n%s", tt.expectedMessage, mockT.ErrorfCalls[0].message())
+			}
+		})
+	}
+}
+
+func TestContextValueType(t *testing.T) {
+	mockT := newMockTB()
+	ctx := context.WithValue(context.Background(), contextKey("roles"), []string{"admin"})
+
+	ContextValue(mockT, ctx, contextKey("roles"), "admin")
+
+	if len(mockT.ErrorfCalls) != 1 {
+		t.Fatalf("expected 1 call to Errorf(), got %d", len(mockT.ErrorfCalls))
+	}
+
+	if mockT.HelperCalls != 1 {
+		t.Errorf("expected 1 call to Helper(), got %d", mockT.HelperCalls)
+	}
+
+	expected := `expected context value for key "roles" of type string, got []string`
+	if got := mockT.ErrorfCalls[0].message(); got != expected {
+		t.Errorf("expected message:\n%s\ngot:\n%s", expected, got)
+	}
+}
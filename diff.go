@@ -0,0 +1,434 @@
+package assert
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// difference describes a single mismatch found while walking two values.
+type difference struct {
+	path     string
+	expected string
+	got      string
+	// detail replaces the expected/got pair when a more readable
+	// representation of the mismatch is available, e.g. a line diff.
+	detail string
+}
+
+func (d difference) String() string {
+	var msg string
+	if d.detail != "" {
+		msg = d.detail
+	} else {
+		msg = fmt.Sprintf("expected %s, got %s", d.expected, d.got)
+	}
+
+	if d.path == "" {
+		return msg
+	}
+	return d.path + ": " + msg
+}
+
+// differ walks two values in parallel and records every path at which they
+// differ.
+type differ struct {
+	diffs   []difference
+	visited map[[2]uintptr]bool
+	// nanEqual makes NaN equal to NaN, unlike reflect.DeepEqual.
+	nanEqual bool
+}
+
+// diffValues returns a report of the paths at which got differs from expected,
+// one difference per line. An empty string is returned if the values are
+// deeply equal.
+func diffValues(expected, got any) string {
+	if reflect.DeepEqual(expected, got) {
+		return ""
+	}
+
+	d := &differ{visited: make(map[[2]uintptr]bool)}
+	d.walk("", reflect.ValueOf(expected), reflect.ValueOf(got))
+	if len(d.diffs) == 0 {
+		// The walker and reflect.DeepEqual should agree, but fall back to
+		// dumping both values rather than reporting no difference at all.
+		d.report("", reflect.ValueOf(expected), reflect.ValueOf(got))
+	}
+
+	return joinDifferences(d.diffs)
+}
+
+// diffDocuments is like diffValues, but treats NaN as equal to NaN. It is used
+// to compare values decoded from documents, in which NaN is a literal value
+// like any other.
+func diffDocuments(expected, got any) string {
+	d := &differ{visited: make(map[[2]uintptr]bool), nanEqual: true}
+	d.walk("", reflect.ValueOf(expected), reflect.ValueOf(got))
+	return joinDifferences(d.diffs)
+}
+
+// joinDifferences renders a list of differences, one per line.
+func joinDifferences(diffs []difference) string {
+	lines := make([]string, len(diffs))
+	for i, d := range diffs {
+		lines[i] = d.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// report records a difference between two leaf values.
+func (d *differ) report(path string, expected, got reflect.Value) {
+	d.diffs = append(d.diffs, difference{
+		path:     path,
+		expected: formatReflect(expected),
+		got:      formatReflect(got),
+	})
+}
+
+func (d *differ) walk(path string, expected, got reflect.Value) {
+	if !expected.IsValid() || !got.IsValid() {
+		if expected.IsValid() != got.IsValid() {
+			d.report(path, expected, got)
+		}
+		return
+	}
+
+	if expected.Type() != got.Type() {
+		d.diffs = append(d.diffs, difference{
+			path:     path,
+			expected: fmt.Sprintf("%s (%s)", formatReflect(expected), expected.Type()),
+			got:      fmt.Sprintf("%s (%s)", formatReflect(got), got.Type()),
+		})
+		return
+	}
+
+	if _, ok := stringerValue(expected); ok {
+		if !reflect.DeepEqual(valueInterface(expected), valueInterface(got)) {
+			d.report(path, expected, got)
+		}
+		return
+	}
+
+	switch expected.Kind() {
+	case reflect.Bool:
+		if expected.Bool() != got.Bool() {
+			d.report(path, expected, got)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if expected.Int() != got.Int() {
+			d.report(path, expected, got)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if expected.Uint() != got.Uint() {
+			d.report(path, expected, got)
+		}
+	case reflect.Float32, reflect.Float64:
+		e, g := expected.Float(), got.Float()
+		if e != g && !(d.nanEqual && e != e && g != g) {
+			d.report(path, expected, got)
+		}
+	case reflect.Complex64, reflect.Complex128:
+		if expected.Complex() != got.Complex() {
+			d.report(path, expected, got)
+		}
+	case reflect.String:
+		d.walkString(path, expected.String(), got.String())
+	case reflect.Interface:
+		d.walk(path, expected.Elem(), got.Elem())
+	case reflect.Pointer:
+		d.walkPointer(path, expected, got)
+	case reflect.Struct:
+		for i := 0; i < expected.NumField(); i++ {
+			name := expected.Type().Field(i).Name
+			d.walk(path+"."+name, expected.Field(i), got.Field(i))
+		}
+	case reflect.Slice:
+		if expected.IsNil() != got.IsNil() {
+			d.report(path, expected, got)
+			return
+		}
+		d.walkList(path, expected, got)
+	case reflect.Array:
+		d.walkList(path, expected, got)
+	case reflect.Map:
+		if expected.IsNil() != got.IsNil() {
+			d.report(path, expected, got)
+			return
+		}
+		d.walkMap(path, expected, got)
+	default:
+		// Functions are only equal when both are nil, while channels and
+		// unsafe pointers are equal when they are identical.
+		if expected.Kind() == reflect.Func {
+			if !expected.IsNil() || !got.IsNil() {
+				d.report(path, expected, got)
+			}
+		} else if expected.Pointer() != got.Pointer() {
+			d.report(path, expected, got)
+		}
+	}
+}
+
+// walkString records a difference between two strings. Multiline strings are
+// reported as a unified diff of their lines.
+func (d *differ) walkString(path, expected, got string) {
+	if expected == got {
+		return
+	}
+
+	if !strings.Contains(expected, "\n") && !strings.Contains(got, "\n") {
+		d.report(path, reflect.ValueOf(expected), reflect.ValueOf(got))
+		return
+	}
+
+	d.diffs = append(d.diffs, difference{
+		path:   path,
+		detail: "strings differ:\n" + indent(unifiedDiff(expected, got), "\t"),
+	})
+}
+
+func (d *differ) walkPointer(path string, expected, got reflect.Value) {
+	if expected.IsNil() || got.IsNil() {
+		if expected.IsNil() != got.IsNil() {
+			d.report(path, expected, got)
+		}
+		return
+	}
+
+	key := [2]uintptr{expected.Pointer(), got.Pointer()}
+	if key[0] == key[1] || d.visited[key] {
+		return
+	}
+	d.visited[key] = true
+
+	d.walk(path, expected.Elem(), got.Elem())
+}
+
+func (d *differ) walkList(path string, expected, got reflect.Value) {
+	n := expected.Len()
+	if got.Len() < n {
+		n = got.Len()
+	}
+
+	for i := 0; i < n; i++ {
+		d.walk(fmt.Sprintf("%s[%d]", path, i), expected.Index(i), got.Index(i))
+	}
+
+	for i := n; i < expected.Len(); i++ {
+		d.diffs = append(d.diffs, difference{
+			path:   fmt.Sprintf("%s[%d]", path, i),
+			detail: "missing element " + formatReflect(expected.Index(i)),
+		})
+	}
+
+	for i := n; i < got.Len(); i++ {
+		d.diffs = append(d.diffs, difference{
+			path:   fmt.Sprintf("%s[%d]", path, i),
+			detail: "unexpected element " + formatReflect(got.Index(i)),
+		})
+	}
+}
+
+func (d *differ) walkMap(path string, expected, got reflect.Value) {
+	for _, k := range sortedMapKeys(expected) {
+		keyPath := fmt.Sprintf("%s[%s]", path, formatReflect(k))
+		gv := got.MapIndex(k)
+		if !gv.IsValid() {
+			d.diffs = append(d.diffs, difference{
+				path:   keyPath,
+				detail: "missing key with value " + formatReflect(expected.MapIndex(k)),
+			})
+			continue
+		}
+		d.walk(keyPath, expected.MapIndex(k), gv)
+	}
+
+	for _, k := range sortedMapKeys(got) {
+		if expected.MapIndex(k).IsValid() {
+			continue
+		}
+		d.diffs = append(d.diffs, difference{
+			path:   fmt.Sprintf("%s[%s]", path, formatReflect(k)),
+			detail: "unexpected key with value " + formatReflect(got.MapIndex(k)),
+		})
+	}
+}
+
+// valueInterface returns the value held by v, or nil if it cannot be accessed
+// because it was obtained through an unexported field.
+func valueInterface(v reflect.Value) any {
+	if !v.CanInterface() {
+		return nil
+	}
+	return v.Interface()
+}
+
+// indent prefixes every line of s with prefix.
+func indent(s, prefix string) string {
+	lines := strings.Split(s, "\n")
+	for i, l := range lines {
+		lines[i] = prefix + l
+	}
+	return strings.Join(lines, "\n")
+}
+
+// diffContext is the number of unchanged lines shown around each change in a
+// unified diff.
+const diffContext = 3
+
+// diffOp is a single line of an edit script.
+type diffOp struct {
+	kind byte // ' ', '-', or '+'
+	line string
+}
+
+// unifiedDiff returns a line oriented unified diff that transforms expected
+// into got.
+func unifiedDiff(expected, got string) string {
+	ops := diffLines(strings.Split(expected, "\n"), strings.Split(got, "\n"))
+
+	var b strings.Builder
+	b.WriteString("--- expected\n+++ got")
+
+	for start := 0; start < len(ops); {
+		// Find the next change and the extent of its hunk.
+		for start < len(ops) && ops[start].kind == ' ' {
+			start++
+		}
+		if start == len(ops) {
+			break
+		}
+
+		first := start - diffContext
+		if first < 0 {
+			first = 0
+		}
+
+		end := start
+		for end < len(ops) {
+			if ops[end].kind != ' ' {
+				end++
+				continue
+			}
+
+			// Merge changes separated by less than two contexts' worth of
+			// unchanged lines into a single hunk.
+			run := end
+			for run < len(ops) && ops[run].kind == ' ' {
+				run++
+			}
+			if run == len(ops) || run-end > 2*diffContext {
+				break
+			}
+			end = run
+		}
+
+		last := end + diffContext
+		if last > len(ops) {
+			last = len(ops)
+		}
+
+		writeHunk(&b, ops, first, last)
+		start = last
+	}
+
+	return b.String()
+}
+
+// writeHunk writes the ops in the range [first, last) as a unified diff hunk.
+func writeHunk(b *strings.Builder, ops []diffOp, first, last int) {
+	// Line numbers are one-based and count the lines of each side that
+	// precede the hunk.
+	expectedLine, gotLine := 1, 1
+	for _, op := range ops[:first] {
+		if op.kind != '+' {
+			expectedLine++
+		}
+		if op.kind != '-' {
+			gotLine++
+		}
+	}
+
+	var expectedCount, gotCount int
+	for _, op := range ops[first:last] {
+		if op.kind != '+' {
+			expectedCount++
+		}
+		if op.kind != '-' {
+			gotCount++
+		}
+	}
+
+	fmt.Fprintf(b, "\n@@ -%d,%d +%d,%d @@", expectedLine, expectedCount, gotLine, gotCount)
+	for _, op := range ops[first:last] {
+		b.WriteByte('\n')
+		b.WriteByte(op.kind)
+		b.WriteString(op.line)
+	}
+}
+
+// diffLines computes an edit script between two sets of lines using the
+// longest common subsequence. Common prefixes and suffixes are trimmed first
+// so that the quadratic table only covers the region that actually changed.
+func diffLines(a, b []string) []diffOp {
+	var prefix int
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+
+	var suffix int
+	for suffix < len(a)-prefix && suffix < len(b)-prefix &&
+		a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	ops := make([]diffOp, 0, len(a)+len(b))
+	for _, l := range a[:prefix] {
+		ops = append(ops, diffOp{kind: ' ', line: l})
+	}
+
+	ma, mb := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+	lcs := make([][]int, len(ma)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(mb)+1)
+	}
+	for i := len(ma) - 1; i >= 0; i-- {
+		for j := len(mb) - 1; j >= 0; j-- {
+			if ma[i] == mb[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(ma) && j < len(mb) {
+		switch {
+		case ma[i] == mb[j]:
+			ops = append(ops, diffOp{kind: ' ', line: ma[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, diffOp{kind: '-', line: ma[i]})
+			i++
+		default:
+			ops = append(ops, diffOp{kind: '+', line: mb[j]})
+			j++
+		}
+	}
+	for ; i < len(ma); i++ {
+		ops = append(ops, diffOp{kind: '-', line: ma[i]})
+	}
+	for ; j < len(mb); j++ {
+		ops = append(ops, diffOp{kind: '+', line: mb[j]})
+	}
+
+	for _, l := range a[len(a)-suffix:] {
+		ops = append(ops, diffOp{kind: ' ', line: l})
+	}
+
+	return ops
+}
@@ -0,0 +1,171 @@
+package assert
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDiffValues(t *testing.T) {
+	type Item struct {
+		Name  string
+		Price float64
+	}
+
+	type Order struct {
+		ID    int
+		Items []Item
+		Tags  map[string]string
+		Note  *string
+	}
+
+	note := "fragile"
+
+	tests := []struct {
+		name     string
+		expected any
+		got      any
+		want     string
+	}{
+		{
+			name:     "Equal values",
+			expected: Order{ID: 1, Items: []Item{{Name: "a", Price: 1}}},
+			got:      Order{ID: 1, Items: []Item{{Name: "a", Price: 1}}},
+			want:     "",
+		},
+		{
+			name:     "Primitives",
+			expected: 1,
+			got:      2,
+			want:     "expected 1, got 2",
+		},
+		{
+			name:     "Nested field",
+			expected: Order{ID: 1, Items: []Item{{Name: "a", Price: 12.5}}},
+			got:      Order{ID: 1, Items: []Item{{Name: "a", Price: 12.05}}},
+			want:     ".Items[0].Price: expected 12.5, got 12.05",
+		},
+		{
+			name:     "Multiple fields",
+			expected: Order{ID: 1, Items: []Item{{Name: "a"}}},
+			got:      Order{ID: 2, Items: []Item{{Name: "b"}}},
+			want:     ".ID: expected 1, got 2\n.Items[0].Name: expected \"a\", got \"b\"",
+		},
+		{
+			name:     "Slice lengths",
+			expected: []int{1, 2},
+			got:      []int{1, 3, 4},
+			want:     "[1]: expected 2, got 3\n[2]: unexpected element 4",
+		},
+		{
+			name:     "Nil slice",
+			expected: []int(nil),
+			got:      []int{},
+			want:     "expected []int(nil), got []int{}",
+		},
+		{
+			name:     "Map keys",
+			expected: map[string]int{"a": 1, "b": 2},
+			got:      map[string]int{"a": 2, "c": 3},
+			want:     "[\"a\"]: expected 1, got 2\n[\"b\"]: missing key with value 2\n[\"c\"]: unexpected key with value 3",
+		},
+		{
+			name:     "Nil pointer",
+			expected: Order{Note: &note},
+			got:      Order{},
+			want:     ".Note: expected &\"fragile\", got (*string)(nil)",
+		},
+		{
+			name:     "Type mismatch",
+			expected: []any{1},
+			got:      []any{"1"},
+			want:     "[0]: expected 1 (int), got \"1\" (string)",
+		},
+		{
+			name:     "Multiline strings",
+			expected: "a\nb\nc",
+			got:      "a\nx\nc",
+			want:     "strings differ:\n\t--- expected\n\t+++ got\n\t@@ -1,3 +1,3 @@\n\t a\n\t-b\n\t+x\n\t c",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := diffValues(tt.expected, tt.got)
+			if got != tt.want {
+				t.Errorf("expected diff:\n%s\ngot:\n%s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestDiffValuesCycle(t *testing.T) {
+	type Node struct {
+		Value int
+		Next  *Node
+	}
+
+	a := &Node{Value: 1}
+	a.Next = a
+	b := &Node{Value: 2}
+	b.Next = b
+
+	got := diffValues(a, b)
+	if got != ".Value: expected 1, got 2" {
+		t.Errorf("unexpected diff %q", got)
+	}
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+		got      string
+		want     string
+	}{
+		{
+			name:     "Changed line",
+			expected: "a\nb\nc",
+			got:      "a\nB\nc",
+			want:     "--- expected\n+++ got\n@@ -1,3 +1,3 @@\n a\n-b\n+B\n c",
+		},
+		{
+			name:     "Separate hunks",
+			expected: "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12",
+			got:      "one\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\ntwelve",
+			want: "--- expected\n+++ got\n@@ -1,4 +1,4 @@\n-1\n+one\n 2\n 3\n 4" +
+				"\n@@ -9,4 +9,4 @@\n 9\n 10\n 11\n-12\n+twelve",
+		},
+		{
+			name:     "Added lines",
+			expected: "a\nc",
+			got:      "a\nb\nc",
+			want:     "--- expected\n+++ got\n@@ -1,2 +1,3 @@\n a\n+b\n c",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := unifiedDiff(tt.expected, tt.got)
+			if got != tt.want {
+				t.Errorf("expected diff:\n%s\ngot:\n%s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestDeepEqualReportsPaths(t *testing.T) {
+	type Foo struct {
+		Bar string
+		Baz int
+	}
+
+	mockT := newMockTB()
+	DeepEqual(mockT, Foo{Bar: "bar", Baz: 1}, Foo{Bar: "bar", Baz: 2})
+
+	if len(mockT.ErrorfCalls) != 1 {
+		t.Fatalf("expected 1 call to Errorf(), got %d", len(mockT.ErrorfCalls))
+	}
+
+	msg := mockT.ErrorfCalls[0].message()
+	if !strings.Contains(msg, ".Baz: expected 2, got 1") || strings.Contains(msg, ".Bar") {
+		t.Errorf("unexpected failure message %q", msg)
+	}
+}
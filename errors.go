@@ -0,0 +1,283 @@
+package assert
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// ErrorAs asserts that the error's chain contains an error of type E according
+// to the semantics of errors.As. The matching error is returned so that further
+// assertions can be made on it. If the assertion fails, the zero value of E is
+// returned.
+func ErrorAs[E error](t testing.TB, err error) E {
+	var target E
+	if err == nil {
+		t.Helper()
+		t.Errorf("expected error of type %T, got nil", target)
+		return target
+	}
+
+	if !errors.As(err, &target) {
+		t.Helper()
+		t.Errorf("expected error of type %T in chain:\n%s", target, indent(errorTree(err), "\t"))
+	}
+
+	return target
+}
+
+// ChainLink matches a single error within an error chain. Links are built with
+// LinkIs and LinkType.
+type ChainLink interface {
+	// String describes the errors matched by the link.
+	String() string
+
+	match(err error) bool
+}
+
+// linkIs matches errors that are, or claim to be, a target error.
+type linkIs struct {
+	target error
+}
+
+// LinkIs builds a ChainLink matching errors that are equal to the target or
+// whose Is method reports that they match it. Unlike errors.Is, the errors
+// wrapped by a candidate are not considered.
+func LinkIs(target error) ChainLink {
+	return linkIs{target: target}
+}
+
+func (l linkIs) String() string {
+	if l.target == nil {
+		return "nil"
+	}
+	return describeError(l.target)
+}
+
+func (l linkIs) match(err error) bool {
+	if reflect.TypeOf(err).Comparable() && err == l.target {
+		return true
+	}
+
+	x, ok := err.(interface{ Is(error) bool })
+	return ok && x.Is(l.target)
+}
+
+// linkType matches errors of a concrete type.
+type linkType[E error] struct{}
+
+// LinkType builds a ChainLink matching errors of type E.
+func LinkType[E error]() ChainLink {
+	return linkType[E]{}
+}
+
+func (l linkType[E]) String() string {
+	var target E
+	return fmt.Sprintf("type %s", reflect.TypeOf(&target).Elem())
+}
+
+func (l linkType[E]) match(err error) bool {
+	_, ok := err.(E)
+	return ok
+}
+
+// ErrorChainContains asserts that each link matches at least one error in the
+// error's chain. The chain includes every error reachable by unwrapping, so
+// all branches of errors built with errors.Join are searched. Links may match
+// in any order.
+func ErrorChainContains(t testing.TB, err error, links ...ChainLink) {
+	if err == nil {
+		t.Helper()
+		t.Errorf("expected error chain containing %s, got nil", joinLinks(links))
+		return
+	}
+
+	chain := flattenErrors(err)
+
+	var missing []ChainLink
+	for _, l := range links {
+		found := false
+		for _, e := range chain {
+			if l.match(e) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			missing = append(missing, l)
+		}
+	}
+
+	if len(missing) > 0 {
+		t.Helper()
+		t.Errorf("error chain does not contain %s:\n%s", joinLinks(missing), indent(errorTree(err), "\t"))
+	}
+}
+
+// ErrorChainExactly asserts that the errors in the error's chain match the
+// links exactly and in order. Branching chains, such as those built with
+// errors.Join, are walked depth first with each error preceding the errors it
+// wraps.
+func ErrorChainExactly(t testing.TB, err error, links ...ChainLink) {
+	if err == nil {
+		if len(links) > 0 {
+			t.Helper()
+			t.Errorf("expected error chain %s, got nil", joinLinks(links))
+		}
+		return
+	}
+
+	chain := flattenErrors(err)
+	for i, e := range chain {
+		if i == len(links) {
+			t.Helper()
+			t.Errorf("error chain has %d links, expected %d:\n%s",
+				len(chain), len(links), indent(errorTreeAnnotated(err, i, "unexpected link"), "\t"))
+			return
+		}
+
+		if !links[i].match(e) {
+			t.Helper()
+			t.Errorf("error chain does not match at link %d:\n%s",
+				i+1, indent(errorTreeAnnotated(err, i, "expected "+links[i].String()), "\t"))
+			return
+		}
+	}
+
+	if len(chain) < len(links) {
+		t.Helper()
+		t.Errorf("error chain has %d links, expected %d, missing %s:\n%s",
+			len(chain), len(links), joinLinks(links[len(chain):]), indent(errorTree(err), "\t"))
+	}
+}
+
+// ErrorContains asserts that the error's message contains the substring.
+func ErrorContains(t testing.TB, err error, substr string) {
+	if err == nil {
+		t.Helper()
+		t.Errorf(`expected error containing "%s", got nil`, substr)
+		return
+	}
+
+	if !strings.Contains(err.Error(), substr) {
+		t.Helper()
+		t.Errorf(`error message "%s" does not contain "%s"`, err.Error(), substr)
+	}
+}
+
+// ErrorMessageMatches asserts that the error's message is matched by the
+// provided pattern. Patterns are cached in the same way as RegexMatches.
+func ErrorMessageMatches(t testing.TB, err error, pattern string) {
+	r, rerr := compileRegex(pattern)
+	if rerr != nil {
+		t.Helper()
+		t.Fatalf("failed to compile regular expression: %v", rerr)
+		return
+	}
+
+	if err == nil {
+		t.Helper()
+		t.Errorf("expected error with message matched by pattern /%s/, got nil", pattern)
+		return
+	}
+
+	if !r.MatchString(err.Error()) {
+		t.Helper()
+		t.Errorf("error message %s not matched by pattern /%s/", err.Error(), pattern)
+	}
+}
+
+// joinLinks describes a list of links.
+func joinLinks(links []ChainLink) string {
+	descs := make([]string, len(links))
+	for i, l := range links {
+		descs[i] = l.String()
+	}
+	return strings.Join(descs, ", ")
+}
+
+// flattenErrors returns every error in the error's chain, walking branching
+// chains depth first with each error preceding the errors it wraps.
+func flattenErrors(err error) []error {
+	chain := []error{err}
+	for _, child := range unwrapAll(err) {
+		chain = append(chain, flattenErrors(child)...)
+	}
+	return chain
+}
+
+// errorTree renders an error and every error it wraps as a tree. Each node
+// shows the concrete type and message of the error. Errors wrapping multiple
+// errors, such as those built by errors.Join, have a branch per wrapped error.
+func errorTree(err error) string {
+	return errorTreeAnnotated(err, -1, "")
+}
+
+// errorTreeAnnotated renders an error tree like errorTree, marking the node at
+// the provided depth first position with a note.
+func errorTreeAnnotated(err error, node int, note string) string {
+	w := &errorTreeWriter{node: node, note: note}
+	w.write(err, "", "")
+	return w.b.String()
+}
+
+// errorTreeWriter renders error trees.
+type errorTreeWriter struct {
+	b    strings.Builder
+	n    int
+	node int
+	note string
+}
+
+// write renders err. The first line is prefixed with prefix and the lines of
+// wrapped errors are prefixed with childPrefix.
+func (w *errorTreeWriter) write(err error, prefix, childPrefix string) {
+	if w.n > 0 {
+		w.b.WriteByte('\n')
+	}
+	w.b.WriteString(prefix)
+	w.b.WriteString(describeError(err))
+	if w.n == w.node {
+		w.b.WriteString("  <-- ")
+		w.b.WriteString(w.note)
+	}
+	w.n++
+
+	children := unwrapAll(err)
+	for i, child := range children {
+		if i == len(children)-1 {
+			w.write(child, childPrefix+"└── ", childPrefix+"    ")
+		} else {
+			w.write(child, childPrefix+"├── ", childPrefix+"│   ")
+		}
+	}
+}
+
+// describeError returns the concrete type and message of an error.
+func describeError(err error) string {
+	return fmt.Sprintf("%T: %s", err, strconv.Quote(err.Error()))
+}
+
+// unwrapAll returns the errors directly wrapped by err. Both the single error
+// and multiple error forms of Unwrap are supported. Nil errors are omitted.
+func unwrapAll(err error) []error {
+	var children []error
+	switch e := err.(type) {
+	case interface{ Unwrap() error }:
+		children = []error{e.Unwrap()}
+	case interface{ Unwrap() []error }:
+		children = e.Unwrap()
+	}
+
+	wrapped := make([]error, 0, len(children))
+	for _, c := range children {
+		if c != nil {
+			wrapped = append(wrapped, c)
+		}
+	}
+	return wrapped
+}
@@ -0,0 +1,321 @@
+package assert
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// joinedError mirrors the error built by errors.Join.
+type joinedError struct {
+	errs []error
+}
+
+func (e *joinedError) Error() string {
+	return "joined"
+}
+
+func (e *joinedError) Unwrap() []error {
+	return e.errs
+}
+
+func TestErrorAs(t *testing.T) {
+	tests := []struct {
+		name          string
+		err           error
+		expectedField string
+		expectedCalls int
+	}{
+		{
+			name:          "Typed error",
+			err:           &validationError{Field: "name"},
+			expectedField: "name",
+			expectedCalls: 0,
+		},
+		{
+			name:          "Wrapped typed error",
+			err:           fmt.Errorf("wrapped: %w", &validationError{Field: "age"}),
+			expectedField: "age",
+			expectedCalls: 0,
+		},
+		{
+			name:          "Joined typed error",
+			err:           &joinedError{errs: []error{errors.New("other"), &validationError{Field: "email"}}},
+			expectedField: "email",
+			expectedCalls: 0,
+		},
+		{
+			name:          "Different error",
+			err:           errors.New("other"),
+			expectedCalls: 1,
+		},
+		{
+			name:          "Nil error",
+			err:           nil,
+			expectedCalls: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+
+			err := ErrorAs[*validationError](mockT, tt.err)
+			n := len(mockT.ErrorfCalls)
+
+			if n != tt.expectedCalls {
+				t.Errorf("expected %d calls to Errorf(), got %d", tt.expectedCalls, n)
+			}
+
+			if n != mockT.HelperCalls {
+				t.Errorf("expected %d calls to Helper(), got %d", tt.expectedCalls, mockT.HelperCalls)
+			}
+
+			if n == 0 && err.Field != tt.expectedField {
+				t.Errorf("expected field %q, got %q", tt.expectedField, err.Field)
+			}
+		})
+	}
+}
+
+func TestErrorAsReportsChain(t *testing.T) {
+	mockT := newMockTB()
+	err := fmt.Errorf("load: %w", &joinedError{errs: []error{
+		errors.New("a"),
+		fmt.Errorf("b: %w", errors.New("c")),
+	}})
+
+	ErrorAs[*validationError](mockT, err)
+
+	if len(mockT.ErrorfCalls) != 1 {
+		t.Fatalf("expected 1 call to Errorf(), got %d", len(mockT.ErrorfCalls))
+	}
+
+	expected := `expected error of type *assert.validationError in chain:
+	*fmt.wrapError: "load: joined"
+	└── *assert.joinedError: "joined"
+	    ├── *errors.errorString: "a"
+	    └── *fmt.wrapError: "b: c"
+	        └── *errors.errorString: "c"`
+	if msg := mockT.ErrorfCalls[0].message(); msg != expected {
+		t.Errorf("expected message:\n%s\ngot:\n%s", expected, msg)
+	}
+}
+
+func TestErrorChainContains(t *testing.T) {
+	errA := errors.New("a")
+	errB := errors.New("b")
+	errC := errors.New("c")
+	tree := fmt.Errorf("load: %w", &joinedError{errs: []error{
+		errA,
+		fmt.Errorf("wrapped: %w", &validationError{Field: "name"}),
+	}})
+
+	tests := []struct {
+		name          string
+		err           error
+		links         []ChainLink
+		expectedCalls int
+	}{
+		{
+			name:          "Contains sentinels in any order",
+			err:           &joinedError{errs: []error{errB, errA}},
+			links:         []ChainLink{LinkIs(errA), LinkIs(errB)},
+			expectedCalls: 0,
+		},
+		{
+			name:          "Contains links across branches",
+			err:           tree,
+			links:         []ChainLink{LinkType[*validationError](), LinkIs(errA)},
+			expectedCalls: 0,
+		},
+		{
+			name:          "Missing link",
+			err:           tree,
+			links:         []ChainLink{LinkIs(errA), LinkIs(errC)},
+			expectedCalls: 1,
+		},
+		{
+			name:          "Multiple missing links",
+			err:           errA,
+			links:         []ChainLink{LinkIs(errB), LinkIs(errC)},
+			expectedCalls: 1,
+		},
+		{
+			name:          "Nil error",
+			err:           nil,
+			links:         []ChainLink{LinkIs(errA)},
+			expectedCalls: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+
+			ErrorChainContains(mockT, tt.err, tt.links...)
+			n := len(mockT.ErrorfCalls)
+
+			if n != tt.expectedCalls {
+				t.Errorf("expected %d calls to Errorf(), got %d", tt.expectedCalls, n)
+			}
+
+			if n != mockT.HelperCalls {
+				t.Errorf("expected %d calls to Helper(), got %d", tt.expectedCalls, mockT.HelperCalls)
+			}
+		})
+	}
+}
+
+func TestErrorChainExactly(t *testing.T) {
+	errA := errors.New("a")
+	errB := errors.New("b")
+	err := fmt.Errorf("load: %w", &joinedError{errs: []error{
+		errA,
+		fmt.Errorf("wrapped: %w", errB),
+	}})
+
+	tests := []struct {
+		name          string
+		err           error
+		links         []ChainLink
+		expected      string
+		expectedCalls int
+	}{
+		{
+			name: "Exact chain",
+			err:  err,
+			links: []ChainLink{
+				LinkIs(err),
+				LinkType[*joinedError](),
+				LinkIs(errA),
+				LinkType[error](),
+				LinkIs(errB),
+			},
+			expectedCalls: 0,
+		},
+		{
+			name: "Mismatched link",
+			err:  err,
+			links: []ChainLink{
+				LinkIs(err),
+				LinkType[*joinedError](),
+				LinkIs(errB),
+				LinkType[error](),
+				LinkIs(errB),
+			},
+			expected: `error chain does not match at link 3:
+	*fmt.wrapError: "load: joined"
+	└── *assert.joinedError: "joined"
+	    ├── *errors.errorString: "a"  <-- expected *errors.errorString: "b"
+	    └── *fmt.wrapError: "wrapped: b"
+	        └── *errors.errorString: "b"`,
+			expectedCalls: 1,
+		},
+		{
+			name:  "Chain too long",
+			err:   fmt.Errorf("wrapped: %w", errA),
+			links: []ChainLink{LinkType[error]()},
+			expected: `error chain has 2 links, expected 1:
+	*fmt.wrapError: "wrapped: a"
+	└── *errors.errorString: "a"  <-- unexpected link`,
+			expectedCalls: 1,
+		},
+		{
+			name:  "Chain too short",
+			err:   errA,
+			links: []ChainLink{LinkIs(errA), LinkType[*validationError]()},
+			expected: `error chain has 1 links, expected 2, missing type *assert.validationError:
+	*errors.errorString: "a"`,
+			expectedCalls: 1,
+		},
+		{
+			name:          "Nil error and no links",
+			err:           nil,
+			expectedCalls: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+
+			ErrorChainExactly(mockT, tt.err, tt.links...)
+			n := len(mockT.ErrorfCalls)
+
+			if n != tt.expectedCalls {
+				t.Fatalf("expected %d calls to Errorf(), got %d", tt.expectedCalls, n)
+			}
+
+			if n != mockT.HelperCalls {
+				t.Errorf("expected %d calls to Helper(), got %d", tt.expectedCalls, mockT.HelperCalls)
+			}
+
+			if n == 1 && mockT.ErrorfCalls[0].message() != tt.expected {
+				t.Errorf("expected message:\n%s\ngot:\n%s", tt.expected, mockT.ErrorfCalls[0].message())
+			}
+		})
+	}
+}
+
+func TestErrorContains(t *testing.T) {
+	tests := []struct {
+		name          string
+		err           error
+		substr        string
+		expectedCalls int
+	}{
+		{name: "Contains", err: errors.New("open config: not found"), substr: "config", expectedCalls: 0},
+		{name: "Does not contain", err: errors.New("open config: not found"), substr: "denied", expectedCalls: 1},
+		{name: "Nil error", err: nil, substr: "config", expectedCalls: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+
+			ErrorContains(mockT, tt.err, tt.substr)
+			n := len(mockT.ErrorfCalls)
+
+			if n != tt.expectedCalls {
+				t.Errorf("expected %d calls to Errorf(), got %d", tt.expectedCalls, n)
+			}
+
+			if n != mockT.HelperCalls {
+				t.Errorf("expected %d calls to Helper(), got %d", tt.expectedCalls, mockT.HelperCalls)
+			}
+		})
+	}
+}
+
+func TestErrorMessageMatches(t *testing.T) {
+	tests := []struct {
+		name               string
+		err                error
+		pattern            string
+		expectedErrorCalls int
+		expectedFatalCalls int
+	}{
+		{name: "Match", err: errors.New("retry 3 of 5"), pattern: `retry \d of \d`},
+		{name: "No match", err: errors.New("failed"), pattern: `retry \d`, expectedErrorCalls: 1},
+		{name: "Nil error", err: nil, pattern: `retry \d`, expectedErrorCalls: 1},
+		{name: "Invalid regex", err: errors.New("failed"), pattern: `\1`, expectedFatalCalls: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+
+			ErrorMessageMatches(mockT, tt.err, tt.pattern)
+			n := len(mockT.ErrorfCalls)
+			m := len(mockT.FatalfCalls)
+
+			if n != tt.expectedErrorCalls {
+				t.Errorf("expected %d calls to Errorf(), got %d", tt.expectedErrorCalls, n)
+			}
+
+			if m != tt.expectedFatalCalls {
+				t.Errorf("expected %d calls to Fatalf(), got %d", tt.expectedFatalCalls, m)
+			}
+
+			if mockT.HelperCalls != n+m {
+				t.Errorf("expected %d calls to Helper(), got %d", n+m, mockT.HelperCalls)
+			}
+		})
+	}
+}
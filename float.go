@@ -0,0 +1,288 @@
+package assert
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"reflect"
+	"testing"
+)
+
+// FloatOrComplex represents all floating-point and complex number types.
+type FloatOrComplex interface {
+	~float32 | ~float64 | ~complex64 | ~complex128
+}
+
+// InDelta asserts that a value is within an absolute delta of an expected
+// value. Complex numbers are compared by the magnitude of their difference.
+// NaN is only within a delta of NaN, and an infinity only of the infinity
+// with the same sign; for complex numbers this applies to the real and
+// imaginary parts separately.
+func InDelta[T FloatOrComplex](t testing.TB, got, expected T, delta float64) {
+	if !validTolerance(delta) {
+		t.Helper()
+		t.Fatalf("invalid delta %v", delta)
+		return
+	}
+
+	if d := absDelta(got, expected); !(d <= delta) {
+		t.Helper()
+		t.Errorf("expected %s to be within delta %v of %s, actual delta is %v", formatValue(got), delta, formatValue(expected), d)
+	}
+}
+
+// InEpsilon asserts that the relative error between a value and an expected
+// value, the magnitude of their difference divided by the magnitude of the
+// expected value, is at most epsilon. If the expected value is zero, only zero
+// is within any relative error of it. NaN and infinities are handled in the
+// same way as InDelta.
+func InEpsilon[T FloatOrComplex](t testing.TB, got, expected T, epsilon float64) {
+	if !validTolerance(epsilon) {
+		t.Helper()
+		t.Fatalf("invalid epsilon %v", epsilon)
+		return
+	}
+
+	if e := relError(got, expected); !(e <= epsilon) {
+		t.Helper()
+		t.Errorf("expected %s to be within relative error %v of %s, actual relative error is %v", formatValue(got), epsilon, formatValue(expected), e)
+	}
+}
+
+// WithinULPs asserts that a value is at most ulps units in the last place
+// from an expected value, that is, that there are at most ulps-1 representable
+// values between them. Positive and negative zero are equal. NaN is only
+// within any distance of NaN, and an infinity only of the infinity with the
+// same sign.
+func WithinULPs[T ~float32 | ~float64](t testing.TB, got, expected T, ulps uint64) {
+	d, ok := ulpDistance(got, expected)
+	if !ok {
+		t.Helper()
+		t.Errorf("expected %s to be within %d ULPs of %s", formatValue(got), ulps, formatValue(expected))
+		return
+	}
+
+	if d > ulps {
+		t.Helper()
+		t.Errorf("expected %s to be within %d ULPs of %s, actual distance is %d ULPs", formatValue(got), ulps, formatValue(expected), d)
+	}
+}
+
+// SliceInDelta asserts that two slices have the same length and that each
+// element is within an absolute delta of the expected element, in the same
+// way as InDelta.
+func SliceInDelta[T FloatOrComplex](t testing.TB, got, expected []T, delta float64) {
+	if !validTolerance(delta) {
+		t.Helper()
+		t.Fatalf("invalid delta %v", delta)
+		return
+	}
+
+	if diffs := approxSlices(got, expected, "delta", delta, absDelta[T]); len(diffs) > 0 {
+		t.Helper()
+		t.Errorf("slices are not within delta %v:\n%s", delta, indent(joinDifferences(diffs), "\t"))
+	}
+}
+
+// SliceInEpsilon asserts that two slices have the same length and that each
+// element is within a relative error of the expected element, in the same way
+// as InEpsilon.
+func SliceInEpsilon[T FloatOrComplex](t testing.TB, got, expected []T, epsilon float64) {
+	if !validTolerance(epsilon) {
+		t.Helper()
+		t.Fatalf("invalid epsilon %v", epsilon)
+		return
+	}
+
+	if diffs := approxSlices(got, expected, "relative error", epsilon, relError[T]); len(diffs) > 0 {
+		t.Helper()
+		t.Errorf("slices are not within relative error %v:\n%s", epsilon, indent(joinDifferences(diffs), "\t"))
+	}
+}
+
+// MapInDelta asserts that two maps have the same keys and that each value is
+// within an absolute delta of the expected value, in the same way as InDelta.
+func MapInDelta[K comparable, T FloatOrComplex](t testing.TB, got, expected map[K]T, delta float64) {
+	if !validTolerance(delta) {
+		t.Helper()
+		t.Fatalf("invalid delta %v", delta)
+		return
+	}
+
+	if diffs := approxMaps(got, expected, "delta", delta, absDelta[T]); len(diffs) > 0 {
+		t.Helper()
+		t.Errorf("maps are not within delta %v:\n%s", delta, indent(joinDifferences(diffs), "\t"))
+	}
+}
+
+// MapInEpsilon asserts that two maps have the same keys and that each value is
+// within a relative error of the expected value, in the same way as InEpsilon.
+func MapInEpsilon[K comparable, T FloatOrComplex](t testing.TB, got, expected map[K]T, epsilon float64) {
+	if !validTolerance(epsilon) {
+		t.Helper()
+		t.Fatalf("invalid epsilon %v", epsilon)
+		return
+	}
+
+	if diffs := approxMaps(got, expected, "relative error", epsilon, relError[T]); len(diffs) > 0 {
+		t.Helper()
+		t.Errorf("maps are not within relative error %v:\n%s", epsilon, indent(joinDifferences(diffs), "\t"))
+	}
+}
+
+// validTolerance reports whether a tolerance is neither negative nor NaN.
+func validTolerance(tol float64) bool {
+	return tol >= 0
+}
+
+// approxSlices returns the elements of got whose error, as measured by
+// measure, exceeds the tolerance, followed by any missing or unexpected
+// elements.
+func approxSlices[T FloatOrComplex](got, expected []T, name string, tol float64, measure func(got, expected T) float64) []difference {
+	var diffs []difference
+	for i := 0; i < len(got) && i < len(expected); i++ {
+		if e := measure(got[i], expected[i]); !(e <= tol) {
+			diffs = append(diffs, approxDifference(fmt.Sprintf("[%d]", i), got[i], expected[i], name, e))
+		}
+	}
+
+	for i := len(got); i < len(expected); i++ {
+		diffs = append(diffs, difference{path: fmt.Sprintf("[%d]", i), detail: "missing element " + formatValue(expected[i])})
+	}
+
+	for i := len(expected); i < len(got); i++ {
+		diffs = append(diffs, difference{path: fmt.Sprintf("[%d]", i), detail: "unexpected element " + formatValue(got[i])})
+	}
+
+	return diffs
+}
+
+// approxMaps returns the keys of got whose values have an error, as measured
+// by measure, that exceeds the tolerance, along with any missing or unexpected
+// keys. Keys are reported in sorted order.
+func approxMaps[K comparable, T FloatOrComplex](got, expected map[K]T, name string, tol float64, measure func(got, expected T) float64) []difference {
+	var diffs []difference
+	for _, k := range sortedMapKeys(reflect.ValueOf(expected)) {
+		key := k.Interface().(K)
+		path := "[" + formatReflect(k) + "]"
+
+		g, ok := got[key]
+		if !ok {
+			diffs = append(diffs, difference{path: path, detail: "missing key with value " + formatValue(expected[key])})
+			continue
+		}
+
+		if e := measure(g, expected[key]); !(e <= tol) {
+			diffs = append(diffs, approxDifference(path, g, expected[key], name, e))
+		}
+	}
+
+	for _, k := range sortedMapKeys(reflect.ValueOf(got)) {
+		key := k.Interface().(K)
+		if _, ok := expected[key]; !ok {
+			diffs = append(diffs, difference{
+				path:   "[" + formatReflect(k) + "]",
+				detail: "unexpected key with value " + formatValue(got[key]),
+			})
+		}
+	}
+
+	return diffs
+}
+
+func approxDifference[T FloatOrComplex](path string, got, expected T, name string, e float64) difference {
+	return difference{
+		path:   path,
+		detail: fmt.Sprintf("expected %s, got %s, %s %v", formatValue(expected), formatValue(got), name, e),
+	}
+}
+
+// absDelta returns the magnitude of the difference between got and expected.
+// It is NaN or infinite if they differ in their NaN or infinite parts.
+func absDelta[T FloatOrComplex](got, expected T) float64 {
+	g, e := toComplex(got), toComplex(expected)
+	fg, fe, ok := finiteParts(g, e)
+	if !ok {
+		return cmplx.Abs(g - e)
+	}
+	return cmplx.Abs(fg - fe)
+}
+
+// relError returns the magnitude of the difference between got and expected
+// divided by the magnitude of expected.
+func relError[T FloatOrComplex](got, expected T) float64 {
+	g, e := toComplex(got), toComplex(expected)
+	fg, fe, ok := finiteParts(g, e)
+	if !ok {
+		return cmplx.Abs(g - e)
+	}
+
+	d := cmplx.Abs(fg - fe)
+	if d == 0 {
+		return 0
+	}
+	return d / cmplx.Abs(fe)
+}
+
+// finiteParts removes the parts of got and expected that are NaN or infinite
+// in both, so that the remaining parts can be compared. ok is false if a part
+// is NaN or infinite in only one of them, or is a different infinity.
+func finiteParts(got, expected complex128) (complex128, complex128, bool) {
+	gr, er, okr := finitePart(real(got), real(expected))
+	gi, ei, oki := finitePart(imag(got), imag(expected))
+	return complex(gr, gi), complex(er, ei), okr && oki
+}
+
+func finitePart(got, expected float64) (float64, float64, bool) {
+	switch {
+	case math.IsNaN(got) || math.IsNaN(expected):
+		return 0, 0, math.IsNaN(got) && math.IsNaN(expected)
+	case math.IsInf(got, 0) || math.IsInf(expected, 0):
+		return 0, 0, got == expected
+	}
+	return got, expected, true
+}
+
+func toComplex[T FloatOrComplex](v T) complex128 {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Complex64, reflect.Complex128:
+		return rv.Complex()
+	}
+	return complex(rv.Float(), 0)
+}
+
+// ulpDistance returns the number of representable values between got and
+// expected, plus one. ok is false if the distance is not meaningful because
+// one of them is NaN or infinite and they are not equal.
+func ulpDistance[T ~float32 | ~float64](got, expected T) (uint64, bool) {
+	g, e := float64(got), float64(expected)
+	if math.IsNaN(g) || math.IsNaN(e) {
+		return 0, math.IsNaN(g) && math.IsNaN(e)
+	}
+	if math.IsInf(g, 0) || math.IsInf(e, 0) {
+		return 0, g == e
+	}
+
+	var a, b int64
+	if reflect.ValueOf(got).Kind() == reflect.Float32 {
+		a, b = orderedBits(uint64(math.Float32bits(float32(got))), 1<<31), orderedBits(uint64(math.Float32bits(float32(expected))), 1<<31)
+	} else {
+		a, b = orderedBits(math.Float64bits(g), 1<<63), orderedBits(math.Float64bits(e), 1<<63)
+	}
+
+	if a < b {
+		a, b = b, a
+	}
+	return uint64(a) - uint64(b), true
+}
+
+// orderedBits maps the bits of a float to an integer that orders floats by
+// value, with adjacent floats mapped to adjacent integers and both zeros
+// mapped to 0.
+func orderedBits(bits, sign uint64) int64 {
+	if bits&sign != 0 {
+		return -int64(bits &^ sign)
+	}
+	return int64(bits)
+}
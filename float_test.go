@@ -0,0 +1,272 @@
+package assert
+
+import (
+	"math"
+	"testing"
+)
+
+type floatCase struct {
+	name               string
+	assert             func(t testing.TB)
+	expectedMessage    string
+	expectedErrorCalls int
+	expectedFatalCalls int
+}
+
+func runFloatCases(t *testing.T, tests []floatCase) {
+	t.Helper()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+
+			tt.assert(mockT)
+			n := len(mockT.ErrorfCalls)
+			m := len(mockT.FatalfCalls)
+
+			if n != tt.expectedErrorCalls {
+				t.Fatalf("expected %d calls to Errorf(), got %d", tt.expectedErrorCalls, n)
+			}
+
+			if m != tt.expectedFatalCalls {
+				t.Errorf("expected %d calls to Fatalf(), got %d", tt.expectedFatalCalls, m)
+			}
+
+			if mockT.HelperCalls != n+m {
+				t.Errorf("expected %d calls to Helper(), got %d", n+m, mockT.HelperCalls)
+			}
+
+			if tt.expectedMessage != "" && mockT.ErrorfCalls[0].message() != tt.expectedMessage {
+				t.Errorf("expected message:\n%s\ngot:\n%s", tt.expectedMessage, mockT.ErrorfCalls[0].message())
+			}
+		})
+	}
+}
+
+func TestInDelta(t *testing.T) {
+	inf, nan := math.Inf(1), math.NaN()
+
+	runFloatCases(t, []floatCase{
+		{
+			name:   "Within delta",
+			assert: func(t testing.TB) { InDelta(t, 0.3000001, 0.3, 1e-6) },
+		},
+		{
+			name:   "Exactly delta",
+			assert: func(t testing.TB) { InDelta(t, 1.5, 1, 0.5) },
+		},
+		{
+			name:               "Outside delta",
+			assert:             func(t testing.TB) { InDelta(t, 1.5, 1, 0.25) },
+			expectedMessage:    "expected 1.5 to be within delta 0.25 of 1, actual delta is 0.5",
+			expectedErrorCalls: 1,
+		},
+		{
+			name:   "float32",
+			assert: func(t testing.TB) { InDelta(t, float32(1.0001), 1, 1e-3) },
+		},
+		{
+			name:   "NaN and NaN",
+			assert: func(t testing.TB) { InDelta(t, nan, nan, 0) },
+		},
+		{
+			name:               "NaN and number",
+			assert:             func(t testing.TB) { InDelta(t, nan, 1, inf) },
+			expectedMessage:    "expected NaN to be within delta +Inf of 1, actual delta is NaN",
+			expectedErrorCalls: 1,
+		},
+		{
+			name:   "Same infinity",
+			assert: func(t testing.TB) { InDelta(t, inf, inf, 0) },
+		},
+		{
+			name:               "Opposite infinities",
+			assert:             func(t testing.TB) { InDelta(t, -inf, inf, 1) },
+			expectedMessage:    "expected -Inf to be within delta 1 of +Inf, actual delta is +Inf",
+			expectedErrorCalls: 1,
+		},
+		{
+			name:               "Infinity and number",
+			assert:             func(t testing.TB) { InDelta(t, math.MaxFloat64, inf, math.MaxFloat64) },
+			expectedErrorCalls: 1,
+		},
+		{
+			name:   "complex128",
+			assert: func(t testing.TB) { InDelta(t, 1+1i, 1.3+1.4i, 0.5) },
+		},
+		{
+			name:               "complex64 outside delta",
+			assert:             func(t testing.TB) { InDelta(t, complex64(1+1i), 1.3+1.4i, 0.25) },
+			expectedMessage:    "expected (1+1i) to be within delta 0.25 of (1.3+1.4i), actual delta is 0.4999999523162847",
+			expectedErrorCalls: 1,
+		},
+		{
+			name:   "complex with matching infinite part",
+			assert: func(t testing.TB) { InDelta(t, complex(inf, 1), complex(inf, 1.1), 0.2) },
+		},
+		{
+			name:               "complex with NaN part",
+			assert:             func(t testing.TB) { InDelta(t, complex(1, nan), complex(1, 1), 1) },
+			expectedErrorCalls: 1,
+		},
+		{
+			name:               "Negative delta",
+			assert:             func(t testing.TB) { InDelta(t, 1.0, 1, -1) },
+			expectedFatalCalls: 1,
+		},
+		{
+			name:               "NaN delta",
+			assert:             func(t testing.TB) { InDelta(t, 1.0, 1, nan) },
+			expectedFatalCalls: 1,
+		},
+	})
+}
+
+func TestInEpsilon(t *testing.T) {
+	runFloatCases(t, []floatCase{
+		{
+			name:   "Within epsilon",
+			assert: func(t testing.TB) { InEpsilon(t, 101.0, 100, 0.01) },
+		},
+		{
+			name:               "Outside epsilon",
+			assert:             func(t testing.TB) { InEpsilon(t, 1.2, 1, 0.1) },
+			expectedMessage:    "expected 1.2 to be within relative error 0.1 of 1, actual relative error is 0.19999999999999996",
+			expectedErrorCalls: 1,
+		},
+		{
+			name:   "Negative values",
+			assert: func(t testing.TB) { InEpsilon(t, -1e-12, -1.05e-12, 0.05) },
+		},
+		{
+			name:   "Zero and zero",
+			assert: func(t testing.TB) { InEpsilon(t, 0.0, 0, 0) },
+		},
+		{
+			name:               "Number and zero",
+			assert:             func(t testing.TB) { InEpsilon(t, 1e-300, 0, 1) },
+			expectedMessage:    "expected 1e-300 to be within relative error 1 of 0, actual relative error is +Inf",
+			expectedErrorCalls: 1,
+		},
+		{
+			name:   "complex",
+			assert: func(t testing.TB) { InEpsilon(t, 3+4.1i, 3+4i, 0.05) },
+		},
+		{
+			name:               "Negative epsilon",
+			assert:             func(t testing.TB) { InEpsilon(t, 1.0, 1, -0.1) },
+			expectedFatalCalls: 1,
+		},
+	})
+}
+
+func TestWithinULPs(t *testing.T) {
+	one, a, b := 1.0, 0.1, 0.2
+
+	runFloatCases(t, []floatCase{
+		{
+			name:   "Adjacent floats",
+			assert: func(t testing.TB) { WithinULPs(t, math.Nextafter(one, 2), one, 1) },
+		},
+		{
+			name:               "Too far apart",
+			assert:             func(t testing.TB) { WithinULPs(t, a+b, 0.3, 0) },
+			expectedMessage:    "expected 0.30000000000000004 to be within 0 ULPs of 0.3, actual distance is 1 ULPs",
+			expectedErrorCalls: 1,
+		},
+		{
+			name:   "Across zero",
+			assert: func(t testing.TB) { WithinULPs(t, -math.SmallestNonzeroFloat64, math.SmallestNonzeroFloat64, 2) },
+		},
+		{
+			name:   "Signed zeros",
+			assert: func(t testing.TB) { WithinULPs(t, math.Copysign(0, -1), 0, 0) },
+		},
+		{
+			name: "float32",
+			assert: func(t testing.TB) {
+				WithinULPs(t, math.Nextafter32(math.Nextafter32(1, 2), 2), float32(1), 2)
+			},
+		},
+		{
+			name:               "float32 too far apart",
+			assert:             func(t testing.TB) { WithinULPs(t, float32(1.001), 1, 100) },
+			expectedMessage:    "expected 1.001 to be within 100 ULPs of 1, actual distance is 8389 ULPs",
+			expectedErrorCalls: 1,
+		},
+		{
+			name:   "NaN and NaN",
+			assert: func(t testing.TB) { WithinULPs(t, math.NaN(), math.NaN(), 0) },
+		},
+		{
+			name:               "Largest float and infinity",
+			assert:             func(t testing.TB) { WithinULPs(t, math.MaxFloat64, math.Inf(1), 10) },
+			expectedMessage:    "expected 1.7976931348623157e+308 to be within 10 ULPs of +Inf",
+			expectedErrorCalls: 1,
+		},
+	})
+}
+
+func TestSliceInDelta(t *testing.T) {
+	runFloatCases(t, []floatCase{
+		{
+			name:   "Within delta",
+			assert: func(t testing.TB) { SliceInDelta(t, []float64{1, 2.05, math.NaN()}, []float64{1, 2, math.NaN()}, 0.1) },
+		},
+		{
+			name:               "Outside delta",
+			assert:             func(t testing.TB) { SliceInDelta(t, []float64{1, 2.5, 3, 5}, []float64{1.5, 2, 3, 4}, 0.25) },
+			expectedMessage:    "slices are not within delta 0.25:\n\t[0]: expected 1.5, got 1, delta 0.5\n\t[1]: expected 2, got 2.5, delta 0.5\n\t[3]: expected 4, got 5, delta 1",
+			expectedErrorCalls: 1,
+		},
+		{
+			name:               "Differing lengths",
+			assert:             func(t testing.TB) { SliceInDelta(t, []float64{1}, []float64{1, 2}, 0.1) },
+			expectedMessage:    "slices are not within delta 0.1:\n\t[1]: missing element 2",
+			expectedErrorCalls: 1,
+		},
+		{
+			name:               "Relative error",
+			assert:             func(t testing.TB) { SliceInEpsilon(t, []float64{10, 21, 30}, []float64{10, 20, 30}, 0.01) },
+			expectedMessage:    "slices are not within relative error 0.01:\n\t[1]: expected 20, got 21, relative error 0.05",
+			expectedErrorCalls: 1,
+		},
+		{
+			name:               "Negative delta",
+			assert:             func(t testing.TB) { SliceInDelta(t, []float64{}, []float64{}, -1) },
+			expectedFatalCalls: 1,
+		},
+	})
+}
+
+func TestMapInDelta(t *testing.T) {
+	runFloatCases(t, []floatCase{
+		{
+			name: "Within delta",
+			assert: func(t testing.TB) {
+				MapInDelta(t, map[string]float64{"a": 1.01, "b": 2}, map[string]float64{"a": 1, "b": 2}, 0.1)
+			},
+		},
+		{
+			name: "Outside delta and differing keys",
+			assert: func(t testing.TB) {
+				MapInDelta(t, map[string]complex128{"a": 1, "b": 2i, "d": 4}, map[string]complex128{"a": 1, "b": 1i, "c": 3}, 0.1)
+			},
+			expectedMessage:    "maps are not within delta 0.1:\n\t[\"b\"]: expected (0+1i), got (0+2i), delta 1\n\t[\"c\"]: missing key with value (3+0i)\n\t[\"d\"]: unexpected key with value (4+0i)",
+			expectedErrorCalls: 1,
+		},
+		{
+			name: "Relative error",
+			assert: func(t testing.TB) {
+				MapInEpsilon(t, map[int]float32{1: 99, 2: 200}, map[int]float32{1: 100, 2: 200}, 0.001)
+			},
+			expectedMessage:    "maps are not within relative error 0.001:\n\t[1]: expected 100, got 99, relative error 0.01",
+			expectedErrorCalls: 1,
+		},
+		{
+			name:               "NaN epsilon",
+			assert:             func(t testing.TB) { MapInEpsilon(t, map[int]float64{}, map[int]float64{}, math.NaN()) },
+			expectedFatalCalls: 1,
+		},
+	})
+}
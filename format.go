@@ -0,0 +1,257 @@
+package assert
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// formatter renders values as Go-like composite literals. Map keys are sorted
+// and pointers are rendered by the value they point to rather than by their
+// address, so the output for equivalent values is always identical.
+type formatter struct {
+	// multiline spreads composite values over several lines with one element
+	// per line.
+	multiline bool
+	// visited holds the pointers currently being rendered and is used to
+	// detect cycles.
+	visited map[uintptr]bool
+}
+
+// formatValue renders a value on a single line.
+func formatValue(v any) string {
+	return formatReflect(reflect.ValueOf(v))
+}
+
+// formatReflect renders a reflected value on a single line.
+func formatReflect(v reflect.Value) string {
+	f := &formatter{visited: make(map[uintptr]bool)}
+	var b strings.Builder
+	f.format(&b, v, 0, false)
+	return b.String()
+}
+
+// prettyValue renders a value with each composite element on its own line.
+func prettyValue(v any) string {
+	f := &formatter{multiline: true, visited: make(map[uintptr]bool)}
+	var b strings.Builder
+	f.format(&b, reflect.ValueOf(v), 0, false)
+	return b.String()
+}
+
+// format writes the rendering of v to b. When elided is true, the type name of
+// a composite value is omitted because it is implied by the enclosing value.
+func (f *formatter) format(b *strings.Builder, v reflect.Value, depth int, elided bool) {
+	if !v.IsValid() {
+		b.WriteString("nil")
+		return
+	}
+
+	if s, ok := stringerValue(v); ok {
+		b.WriteString(s)
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		b.WriteString(strconv.FormatBool(v.Bool()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		b.WriteString(strconv.FormatInt(v.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		b.WriteString(strconv.FormatUint(v.Uint(), 10))
+	case reflect.Float32:
+		b.WriteString(strconv.FormatFloat(v.Float(), 'g', -1, 32))
+	case reflect.Float64:
+		b.WriteString(strconv.FormatFloat(v.Float(), 'g', -1, 64))
+	case reflect.Complex64:
+		b.WriteString(strconv.FormatComplex(v.Complex(), 'g', -1, 64))
+	case reflect.Complex128:
+		b.WriteString(strconv.FormatComplex(v.Complex(), 'g', -1, 128))
+	case reflect.String:
+		b.WriteString(strconv.Quote(v.String()))
+	case reflect.Interface:
+		f.format(b, v.Elem(), depth, false)
+	case reflect.Pointer:
+		f.formatPointer(b, v, depth)
+	case reflect.Struct:
+		f.formatStruct(b, v, depth, elided)
+	case reflect.Slice:
+		if v.IsNil() {
+			fmt.Fprintf(b, "%s(nil)", v.Type())
+			return
+		}
+		f.formatList(b, v, depth, elided)
+	case reflect.Array:
+		f.formatList(b, v, depth, elided)
+	case reflect.Map:
+		if v.IsNil() {
+			fmt.Fprintf(b, "%s(nil)", v.Type())
+			return
+		}
+		f.formatMap(b, v, depth, elided)
+	default:
+		// Functions, channels, and unsafe pointers have no meaningful
+		// representation beyond whether or not they are nil.
+		if v.IsNil() {
+			fmt.Fprintf(b, "%s(nil)", v.Type())
+		} else {
+			fmt.Fprintf(b, "%s{...}", v.Type())
+		}
+	}
+}
+
+func (f *formatter) formatPointer(b *strings.Builder, v reflect.Value, depth int) {
+	if v.IsNil() {
+		fmt.Fprintf(b, "(%s)(nil)", v.Type())
+		return
+	}
+
+	ptr := v.Pointer()
+	if f.visited[ptr] {
+		fmt.Fprintf(b, "<cycle %s>", v.Type())
+		return
+	}
+	f.visited[ptr] = true
+	defer delete(f.visited, ptr)
+
+	b.WriteByte('&')
+	f.format(b, v.Elem(), depth, false)
+}
+
+func (f *formatter) formatStruct(b *strings.Builder, v reflect.Value, depth int, elided bool) {
+	if !elided {
+		b.WriteString(v.Type().String())
+	}
+
+	t := v.Type()
+	f.open(b, t.NumField() == 0)
+	for i := 0; i < t.NumField(); i++ {
+		f.separate(b, depth+1, i)
+		b.WriteString(t.Field(i).Name)
+		b.WriteString(": ")
+		f.format(b, v.Field(i), depth+1, false)
+	}
+	f.close(b, depth, t.NumField() == 0)
+}
+
+func (f *formatter) formatList(b *strings.Builder, v reflect.Value, depth int, elided bool) {
+	if !elided {
+		b.WriteString(v.Type().String())
+	}
+
+	elideElems := v.Type().Elem().Kind() != reflect.Interface
+	f.open(b, v.Len() == 0)
+	for i := 0; i < v.Len(); i++ {
+		f.separate(b, depth+1, i)
+		f.format(b, v.Index(i), depth+1, elideElems)
+	}
+	f.close(b, depth, v.Len() == 0)
+}
+
+func (f *formatter) formatMap(b *strings.Builder, v reflect.Value, depth int, elided bool) {
+	if !elided {
+		b.WriteString(v.Type().String())
+	}
+
+	keys := sortedMapKeys(v)
+	elideElems := v.Type().Elem().Kind() != reflect.Interface
+	f.open(b, len(keys) == 0)
+	for i, k := range keys {
+		f.separate(b, depth+1, i)
+		f.format(b, k, depth+1, false)
+		b.WriteString(": ")
+		f.format(b, v.MapIndex(k), depth+1, elideElems)
+	}
+	f.close(b, depth, len(keys) == 0)
+}
+
+func (f *formatter) open(b *strings.Builder, empty bool) {
+	b.WriteByte('{')
+	if f.multiline && !empty {
+		b.WriteByte('\n')
+	}
+}
+
+func (f *formatter) separate(b *strings.Builder, depth, i int) {
+	if f.multiline {
+		if i > 0 {
+			b.WriteString(",\n")
+		}
+		b.WriteString(strings.Repeat("\t", depth))
+	} else if i > 0 {
+		b.WriteString(", ")
+	}
+}
+
+func (f *formatter) close(b *strings.Builder, depth int, empty bool) {
+	if f.multiline && !empty {
+		// Every element line is terminated by a comma so that the output
+		// mirrors gofmt'd composite literals.
+		b.WriteString(",\n")
+		b.WriteString(strings.Repeat("\t", depth))
+	}
+	b.WriteByte('}')
+}
+
+// sortedMapKeys returns the keys of a map sorted by their rendered form.
+func sortedMapKeys(v reflect.Value) []reflect.Value {
+	keys := v.MapKeys()
+	rendered := make(map[int]string, len(keys))
+	idx := make([]int, len(keys))
+	for i, k := range keys {
+		idx[i] = i
+		rendered[i] = formatReflect(k)
+	}
+	sort.SliceStable(idx, func(i, j int) bool {
+		ki, kj := keys[idx[i]], keys[idx[j]]
+		if ki.Kind() == kj.Kind() {
+			switch ki.Kind() {
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+				return ki.Int() < kj.Int()
+			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+				return ki.Uint() < kj.Uint()
+			case reflect.Float32, reflect.Float64:
+				return ki.Float() < kj.Float()
+			}
+		}
+		return rendered[idx[i]] < rendered[idx[j]]
+	})
+
+	sorted := make([]reflect.Value, len(keys))
+	for i, j := range idx {
+		sorted[i] = keys[j]
+	}
+	return sorted
+}
+
+// stringerValue returns a rendering based on the Error or String methods of
+// values that implement error or fmt.Stringer. Errors are rendered by their
+// message and type, and structs such as time.Time are far more readable through
+// their String method than as a dump of their unexported fields.
+func stringerValue(v reflect.Value) (string, bool) {
+	if !v.CanInterface() {
+		return "", false
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+	case reflect.Pointer:
+		if v.IsNil() {
+			return "", false
+		}
+	default:
+		return "", false
+	}
+
+	switch s := v.Interface().(type) {
+	case error:
+		return fmt.Sprintf("%s(%s)", v.Type(), strconv.Quote(s.Error())), true
+	case fmt.Stringer:
+		if v.Kind() == reflect.Struct {
+			return s.String(), true
+		}
+	}
+	return "", false
+}
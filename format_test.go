@@ -0,0 +1,60 @@
+package assert
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFormatValue(t *testing.T) {
+	type Inner struct {
+		N int
+	}
+
+	type Outer struct {
+		Name  string
+		Inner *Inner
+		List  []Inner
+		Map   map[string]int
+		Err   error
+		Any   any
+	}
+
+	tests := []struct {
+		name  string
+		value any
+		want  string
+	}{
+		{name: "Nil", value: nil, want: "nil"},
+		{name: "String", value: "a\"b", want: `"a\"b"`},
+		{name: "Float", value: 12.5, want: "12.5"},
+		{name: "Nil slice", value: []int(nil), want: "[]int(nil)"},
+		{name: "Sorted map", value: map[int]string{10: "b", 2: "a"}, want: `map[int]string{2: "a", 10: "b"}`},
+		{
+			name:  "Struct",
+			value: Outer{Name: "x", Inner: &Inner{N: 1}, List: []Inner{{N: 2}}, Err: errors.New("boom")},
+			want:  `assert.Outer{Name: "x", Inner: &assert.Inner{N: 1}, List: []assert.Inner{{N: 2}}, Map: map[string]int(nil), Err: *errors.errorString("boom"), Any: nil}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatValue(tt.value); got != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestPrettyValue(t *testing.T) {
+	type Foo struct {
+		Bar  string
+		Baz  []int
+		Qux  map[string]int
+		None []int
+	}
+
+	got := prettyValue(Foo{Bar: "bar", Baz: []int{1, 2}, Qux: map[string]int{}, None: []int{}})
+	want := "assert.Foo{\n\tBar: \"bar\",\n\tBaz: []int{\n\t\t1,\n\t\t2,\n\t},\n\tQux: map[string]int{},\n\tNone: []int{},\n}"
+	if got != want {
+		t.Errorf("expected:\n%s\ngot:\n%s", want, got)
+	}
+}
@@ -0,0 +1,80 @@
+package assert
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// update is set with the -assert.update flag. The flag is namespaced so that
+// it cannot clash with an -update flag defined by the tests using the package.
+var update = flag.Bool("assert.update", false, "update golden and snapshot files instead of comparing against them")
+
+// updating reports whether golden and snapshot files should be rewritten
+// rather than compared against. This is the case when tests are run with the
+// -assert.update flag, or with the -update flag if the test package defines
+// it as a boolean flag.
+func updating() bool {
+	if *update {
+		return true
+	}
+
+	if f := flag.Lookup("update"); f != nil {
+		if g, ok := f.Value.(flag.Getter); ok {
+			enabled, _ := g.Get().(bool)
+			return enabled
+		}
+	}
+
+	return false
+}
+
+// goldenDir is the directory holding golden files.
+var goldenDir = "testdata"
+
+// Golden asserts that a value matches the contents of the golden file
+// testdata/<TestName>.golden, where subtests are stored in a directory named
+// after their parent test. Line endings are normalized before comparing, and
+// mismatches are reported as a unified diff. When tests are run with the
+// -assert.update flag, or an -update flag defined by the test package, the
+// golden file is written with the value instead.
+func Golden[T ~string | ~[]byte](t testing.TB, got T) {
+	path := filepath.Join(goldenDir, filepath.FromSlash(t.Name())+".golden")
+
+	if updating() {
+		if err := writeFile(path, []byte(got)); err != nil {
+			t.Helper()
+			t.Fatalf("failed to update golden file: %v", err)
+		}
+		return
+	}
+
+	expected, err := os.ReadFile(path)
+	if err != nil {
+		t.Helper()
+		t.Fatalf("failed to read golden file, run with -assert.update to create it: %v", err)
+		return
+	}
+
+	e, g := normalizeNewlines([]byte(expected)), normalizeNewlines([]byte(got))
+	if !bytes.Equal(e, g) {
+		t.Helper()
+		t.Errorf("value does not match golden file %s:\n%s", path, indent(unifiedDiff(string(e), string(g)), "\t"))
+	}
+}
+
+// normalizeNewlines converts all CRLF line endings to LF.
+func normalizeNewlines(b []byte) []byte {
+	return bytes.ReplaceAll(b, []byte("\r\n"), []byte("\n"))
+}
+
+// writeFile writes data to the file at path, creating any missing parent
+// directories.
+func writeFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
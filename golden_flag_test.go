@@ -0,0 +1,30 @@
+package assert_test
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mattmeyers/assert"
+)
+
+// update is the flag test packages commonly define for their own golden files.
+// Defining it must not clash with the flags of the assert package.
+var update = flag.Bool("update", false, "update golden files")
+
+func TestUserDefinedUpdateFlag(t *testing.T) {
+	wd, err := os.Getwd()
+	assert.NoError(assert.Fatal(t), err)
+	assert.NoError(assert.Fatal(t), os.Chdir(t.TempDir()))
+	t.Cleanup(func() { os.Chdir(wd) })
+
+	*update = true
+	t.Cleanup(func() { *update = false })
+
+	assert.Golden(t, "line 1\n")
+
+	b, err := os.ReadFile(filepath.Join("testdata", "TestUserDefinedUpdateFlag.golden"))
+	assert.NoError(assert.Fatal(t), err)
+	assert.Equal(t, string(b), "line 1\n")
+}
@@ -0,0 +1,111 @@
+package assert
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// namedMockTB is a mockTB reporting a fixed test name.
+type namedMockTB struct {
+	*mockTB
+	name string
+}
+
+func (t *namedMockTB) Name() string {
+	return t.name
+}
+
+// useGoldenDir points golden files at a temporary directory for the duration
+// of a test.
+func useGoldenDir(t *testing.T) string {
+	dir := t.TempDir()
+	prev := goldenDir
+	goldenDir = dir
+	t.Cleanup(func() { goldenDir = prev })
+	return dir
+}
+
+// setUpdate sets the -update flag for the duration of a test.
+func setUpdate(t *testing.T, enabled bool) {
+	prev := *update
+	*update = enabled
+	t.Cleanup(func() { *update = prev })
+}
+
+func TestGolden(t *testing.T) {
+	tests := []struct {
+		name               string
+		golden             string
+		got                string
+		expectedErrorCalls int
+		expectedFatalCalls int
+	}{
+		{name: "Matches", golden: "line 1\nline 2\n", got: "line 1\nline 2\n"},
+		{name: "Matches with CRLF", golden: "line 1\r\nline 2\r\n", got: "line 1\nline 2\n"},
+		{name: "Does not match", golden: "line 1\nline 2\n", got: "line 1\nline 3\n", expectedErrorCalls: 1},
+		{name: "Missing golden file", got: "line 1\n", expectedFatalCalls: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := useGoldenDir(t)
+			mockT := &namedMockTB{mockTB: newMockTB(), name: "TestFoo/" + strings.ReplaceAll(tt.name, " ", "_")}
+
+			if tt.golden != "" {
+				err := writeFile(filepath.Join(dir, "TestFoo", strings.ReplaceAll(tt.name, " ", "_")+".golden"), []byte(tt.golden))
+				NoError(Fatal(t), err)
+			}
+
+			Golden(mockT, tt.got)
+			n := len(mockT.ErrorfCalls)
+			m := len(mockT.FatalfCalls)
+
+			if n != tt.expectedErrorCalls {
+				t.Errorf("expected %d calls to Errorf(), got %d", tt.expectedErrorCalls, n)
+			}
+
+			if m != tt.expectedFatalCalls {
+				t.Errorf("expected %d calls to Fatalf(), got %d", tt.expectedFatalCalls, m)
+			}
+
+			if mockT.HelperCalls != n+m {
+				t.Errorf("expected %d calls to Helper(), got %d", n+m, mockT.HelperCalls)
+			}
+		})
+	}
+}
+
+func TestGoldenReportsDiff(t *testing.T) {
+	dir := useGoldenDir(t)
+	mockT := &namedMockTB{mockTB: newMockTB(), name: "TestFoo"}
+	NoError(Fatal(t), writeFile(filepath.Join(dir, "TestFoo.golden"), []byte("a\nb\nc\n")))
+
+	Golden(mockT, []byte("a\nB\nc\n"))
+
+	if len(mockT.ErrorfCalls) != 1 {
+		t.Fatalf("expected 1 call to Errorf(), got %d", len(mockT.ErrorfCalls))
+	}
+
+	expected := "value does not match golden file " + filepath.Join(dir, "TestFoo.golden") +
+		":\n\t--- expected\n\t+++ got\n\t@@ -1,4 +1,4 @@\n\t a\n\t-b\n\t+B\n\t c\n\t "
+	if msg := mockT.ErrorfCalls[0].message(); msg != expected {
+		t.Errorf("expected message:\n%q\ngot:\n%q", expected, msg)
+	}
+}
+
+func TestGoldenUpdate(t *testing.T) {
+	dir := useGoldenDir(t)
+	setUpdate(t, true)
+	mockT := &namedMockTB{mockTB: newMockTB(), name: "TestFoo/bar"}
+
+	Golden(mockT, "updated\n")
+
+	if n := len(mockT.ErrorfCalls) + len(mockT.FatalfCalls); n != 0 {
+		t.Fatalf("expected no failures, got %d", n)
+	}
+
+	b, err := os.ReadFile(filepath.Join(dir, "TestFoo", "bar.golden"))
+	NoError(Fatal(t), err)
+	Equal(t, string(b), "updated\n")
+}
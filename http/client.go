@@ -0,0 +1,195 @@
+package http
+
+import (
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"io"
+	nethttp "net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+// baseURL is the URL that relative request targets are resolved against, the
+// same default host as httptest.NewRequest.
+var baseURL = &url.URL{Scheme: "http", Host: "example.com", Path: "/"}
+
+// HandlerServes serves a single request with a handler and returns the
+// response, which the other assertions of this package can be made about.
+// Relative targets are resolved against http://example.com/. Redirects are not
+// followed; use a Client to follow them or to send a sequence of requests.
+func HandlerServes(t testing.TB, h nethttp.Handler, method, target string, body io.Reader) *nethttp.Response {
+	req, err := newRequest(method, target, body)
+	if err != nil {
+		t.Helper()
+		t.Fatalf("%v", err)
+		return nil
+	}
+
+	res, err := handlerTransport{h}.RoundTrip(req)
+	if err != nil {
+		t.Helper()
+		t.Fatalf("%v", err)
+		return nil
+	}
+	return res
+}
+
+// ClientOption configures a Client.
+type ClientOption func(*nethttp.Client)
+
+// NoRedirects stops a Client from following redirects, so that the redirect
+// response itself is returned.
+func NoRedirects() ClientOption {
+	return func(c *nethttp.Client) {
+		c.CheckRedirect = func(*nethttp.Request, []*nethttp.Request) error {
+			return nethttp.ErrUseLastResponse
+		}
+	}
+}
+
+// Client sends requests to a handler without a live server. Like an
+// http.Client it follows redirects and keeps the cookies set by responses in a
+// cookie jar, sending them with later requests, so a sequence of requests can
+// be tested through a handler's middleware.
+type Client struct {
+	t      testing.TB
+	client *nethttp.Client
+}
+
+// NewClient returns a Client that sends requests to a handler.
+func NewClient(t testing.TB, h nethttp.Handler, opts ...ClientOption) *Client {
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		t.Helper()
+		t.Fatalf("failed to create cookie jar: %v", err)
+		return nil
+	}
+
+	c := &nethttp.Client{Transport: handlerTransport{h}, Jar: jar}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return &Client{t: t, client: c}
+}
+
+// Do sends a request and returns the response. A request with a relative URL
+// is resolved against http://example.com/.
+func (c *Client) Do(req *nethttp.Request) *nethttp.Response {
+	if !req.URL.IsAbs() {
+		req.URL = baseURL.ResolveReference(req.URL)
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		c.t.Helper()
+		c.t.Fatalf("%v", err)
+		return nil
+	}
+	return res
+}
+
+// Get sends a GET request to the target and returns the response.
+func (c *Client) Get(target string) *nethttp.Response {
+	req, err := newRequest(nethttp.MethodGet, target, nil)
+	if err != nil {
+		c.t.Helper()
+		c.t.Fatalf("%v", err)
+		return nil
+	}
+
+	c.t.Helper()
+	return c.Do(req)
+}
+
+// Post sends a POST request with a body of the given content type to the
+// target and returns the response.
+func (c *Client) Post(target, contentType string, body io.Reader) *nethttp.Response {
+	req, err := newRequest(nethttp.MethodPost, target, body)
+	if err != nil {
+		c.t.Helper()
+		c.t.Fatalf("%v", err)
+		return nil
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	c.t.Helper()
+	return c.Do(req)
+}
+
+// Cookies returns the cookies in the client's jar that would be sent to the
+// target.
+func (c *Client) Cookies(target string) []*nethttp.Cookie {
+	u, err := baseURL.Parse(target)
+	if err != nil {
+		c.t.Helper()
+		c.t.Fatalf("invalid target %q: %v", target, err)
+		return nil
+	}
+	return c.client.Jar.Cookies(u)
+}
+
+// newRequest builds a client request whose body is buffered, so that it can be
+// included in failure messages.
+func newRequest(method, target string, body io.Reader) (*nethttp.Request, error) {
+	u, err := baseURL.Parse(target)
+	if err != nil {
+		return nil, fmt.Errorf("invalid target %q: %w", target, err)
+	}
+
+	var b []byte
+	if body != nil {
+		if b, err = io.ReadAll(body); err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+	}
+
+	return nethttp.NewRequest(method, u.String(), bytes.NewReader(b))
+}
+
+// handlerTransport is a RoundTripper that serves requests with a handler.
+type handlerTransport struct {
+	handler nethttp.Handler
+}
+
+// RoundTrip converts a client request into the incoming request a server would
+// see, in the same way as httptest.NewRequest, and records the handler's
+// response to it.
+func (rt handlerTransport) RoundTrip(req *nethttp.Request) (*nethttp.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+	}
+
+	in := req.Clone(req.Context())
+	in.Proto, in.ProtoMajor, in.ProtoMinor = "HTTP/1.1", 1, 1
+	in.Body = io.NopCloser(bytes.NewReader(body))
+	in.ContentLength = int64(len(body))
+	in.GetBody = nil
+	in.RequestURI = req.URL.RequestURI()
+	in.RemoteAddr = "192.0.2.1:1234"
+	if in.Host == "" {
+		in.Host = req.URL.Host
+	}
+	if req.URL.Scheme == "https" {
+		in.TLS = &tls.ConnectionState{
+			Version:           tls.VersionTLS12,
+			HandshakeComplete: true,
+			ServerName:        req.URL.Hostname(),
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	rt.handler.ServeHTTP(rec, in)
+
+	res := rec.Result()
+	res.Request = req
+	return res, nil
+}
@@ -0,0 +1,156 @@
+package http
+
+import (
+	"fmt"
+	"io"
+	nethttp "net/http"
+	"strings"
+	"testing"
+)
+
+// appHandler is a login flow behind an authentication middleware.
+func appHandler() nethttp.Handler {
+	mux := nethttp.NewServeMux()
+	mux.HandleFunc("/login", func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		if r.Method != nethttp.MethodPost || r.FormValue("user") == "" {
+			nethttp.Error(w, "bad login", nethttp.StatusBadRequest)
+			return
+		}
+		nethttp.SetCookie(w, &nethttp.Cookie{Name: "user", Value: r.FormValue("user"), Path: "/"})
+		nethttp.Redirect(w, r, "/home", nethttp.StatusSeeOther)
+	})
+	mux.HandleFunc("/home", func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		c, _ := r.Cookie("user")
+		fmt.Fprintf(w, "hello %s via %s", c.Value, r.Host)
+	})
+	mux.HandleFunc("/echo", func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		b, _ := io.ReadAll(r.Body)
+		fmt.Fprintf(w, "%s %s %s tls=%t", r.Method, r.RequestURI, b, r.TLS != nil)
+	})
+	mux.HandleFunc("/loop", func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		nethttp.Redirect(w, r, "/loop", nethttp.StatusFound)
+	})
+
+	return nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		if _, err := r.Cookie("user"); err != nil && r.URL.Path == "/home" {
+			nethttp.Redirect(w, r, "/login", nethttp.StatusFound)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+func TestHandlerServes(t *testing.T) {
+	mockT := newMockTB()
+
+	res := HandlerServes(mockT, appHandler(), "PUT", "/echo?x=1", strings.NewReader("data"))
+	StatusCode(mockT, res, nethttp.StatusOK)
+	BodyEqual(mockT, res, "PUT /echo?x=1 data tls=false")
+
+	res = HandlerServes(mockT, appHandler(), "GET", "https://example.org/echo", nil)
+	BodyEqual(mockT, res, "GET /echo  tls=true")
+
+	res = HandlerServes(mockT, appHandler(), "GET", "/home", nil)
+	Redirects(mockT, res, "/login")
+
+	if len(mockT.ErrorfCalls) != 0 || len(mockT.FatalfCalls) != 0 {
+		t.Fatalf("expected no failures, got %q %q", mockT.ErrorfCalls, mockT.FatalfCalls)
+	}
+
+	StatusCode(mockT, HandlerServes(mockT, appHandler(), "POST", "/login", strings.NewReader("user=")), nethttp.StatusOK)
+
+	if len(mockT.ErrorfCalls) != 1 {
+		t.Fatalf("expected 1 call to Errorf(), got %d", len(mockT.ErrorfCalls))
+	}
+
+	expected := "expected status 200 OK, got 400 Bad Request\n" +
+		"request:\n" +
+		"\tPOST /login HTTP/1.1\n" +
+		"\tHost: example.com\n" +
+		"\t\n" +
+		"\tuser=\n" +
+		"response:\n"
+	if msg := mockT.ErrorfCalls[0]; !strings.HasPrefix(msg, expected) {
+		t.Errorf("expected message to start with:\n%s\ngot:\n%s", expected, msg)
+	}
+}
+
+func TestHandlerServesInvalidTarget(t *testing.T) {
+	mockT := newMockTB()
+
+	if res := HandlerServes(mockT, appHandler(), "GET", "%zz", nil); res != nil {
+		t.Errorf("expected nil response, got %v", res)
+	}
+
+	if len(mockT.FatalfCalls) != 1 {
+		t.Errorf("expected 1 call to Fatalf(), got %d", len(mockT.FatalfCalls))
+	}
+}
+
+func TestClient(t *testing.T) {
+	mockT := newMockTB()
+	c := NewClient(mockT, appHandler())
+
+	res := c.Get("/home")
+	StatusCode(mockT, res, nethttp.StatusBadRequest)
+	HeaderEqual(mockT, res, "X-Content-Type-Options", "nosniff")
+
+	res = c.Post("/login", "application/x-www-form-urlencoded", strings.NewReader("user=alice"))
+	StatusCode(mockT, res, nethttp.StatusOK)
+	BodyEqual(mockT, res, "hello alice via example.com")
+
+	if res.Request.URL.Path != "/home" {
+		t.Errorf("expected final request to /home, got %s", res.Request.URL.Path)
+	}
+
+	expected := "request:\n" +
+		"\tGET /home HTTP/1.1\n" +
+		"\tHost: example.com\n" +
+		"\tCookie: user=alice\n" +
+		"\tReferer: http://example.com/login\n" +
+		"response:\n"
+	BodyEqual(mockT, res, "")
+	if msg := mockT.ErrorfCalls[0]; !strings.Contains(msg, expected) {
+		t.Errorf("expected message to contain:\n%s\ngot:\n%s", expected, msg)
+	}
+	mockT.ErrorfCalls = nil
+
+	req, err := nethttp.NewRequest("DELETE", "/echo", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	BodyEqual(mockT, c.Do(req), "DELETE /echo  tls=false")
+
+	if cookies := c.Cookies("/"); len(cookies) != 1 || cookies[0].Value != "alice" {
+		t.Errorf("expected user cookie in jar, got %v", cookies)
+	}
+
+	if len(mockT.ErrorfCalls) != 0 || len(mockT.FatalfCalls) != 0 {
+		t.Fatalf("expected no failures, got %q %q", mockT.ErrorfCalls, mockT.FatalfCalls)
+	}
+
+	c.Get("/loop")
+
+	if len(mockT.FatalfCalls) != 1 {
+		t.Fatalf("expected 1 call to Fatalf(), got %d", len(mockT.FatalfCalls))
+	}
+
+	if expected := `Get "/loop": stopped after 10 redirects`; mockT.FatalfCalls[0] != expected {
+		t.Errorf("expected message %q, got %q", expected, mockT.FatalfCalls[0])
+	}
+}
+
+func TestClientNoRedirects(t *testing.T) {
+	mockT := newMockTB()
+	c := NewClient(mockT, appHandler(), NoRedirects())
+
+	res := c.Post("/login", "application/x-www-form-urlencoded", strings.NewReader("user=bob"))
+	Redirects(mockT, res, "/home")
+	SetsCookie(mockT, res, "user")
+
+	BodyEqual(mockT, c.Get("/home"), "hello bob via example.com")
+
+	if len(mockT.ErrorfCalls) != 0 || len(mockT.FatalfCalls) != 0 {
+		t.Fatalf("expected no failures, got %q %q", mockT.ErrorfCalls, mockT.FatalfCalls)
+	}
+}
@@ -0,0 +1,351 @@
+// Package http provides assertions for HTTP responses, either recorded from a
+// handler by an httptest.ResponseRecorder or received by an http.Client.
+// Failures are annotated with the request and response in wire format.
+package http
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	nethttp "net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"sort"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/mattmeyers/assert"
+)
+
+// Response is a response that assertions can be made about. The body of an
+// http.Response is read in full and replaced, so it can still be read after
+// an assertion.
+//
+// A ResponseRecorder does not know the request that was served, so failures
+// only include the response. WithRequest attaches the request so that it is
+// included as well.
+type Response interface {
+	*httptest.ResponseRecorder | *nethttp.Response
+}
+
+// WithRequest returns the response recorded by rec with req attached as its
+// request, so that failures of assertions about it include the request served
+// to produce it.
+//
+//	req := httptest.NewRequest("GET", "/users/1", nil)
+//	rec := httptest.NewRecorder()
+//	handler.ServeHTTP(rec, req)
+//	http.StatusCode(t, http.WithRequest(rec, req), 200)
+func WithRequest(rec *httptest.ResponseRecorder, req *nethttp.Request) *nethttp.Response {
+	res := rec.Result()
+	res.Request = req
+	return res
+}
+
+// StatusCode asserts that the response has the expected status code.
+func StatusCode[R Response](t testing.TB, r R, expected int) {
+	e, err := newExchange(r)
+	if err != nil {
+		t.Helper()
+		t.Fatalf("%v", err)
+		return
+	}
+
+	if e.res.StatusCode != expected {
+		t.Helper()
+		e.annotate(t).Errorf("expected status %s, got %s", statusText(expected), statusText(e.res.StatusCode))
+	}
+}
+
+// HeaderEqual asserts that a header of the response has the expected value.
+// Headers with more than one value are compared with their values joined by
+// ", ".
+func HeaderEqual[R Response](t testing.TB, r R, key, expected string) {
+	e, err := newExchange(r)
+	if err != nil {
+		t.Helper()
+		t.Fatalf("%v", err)
+		return
+	}
+
+	values := e.res.Header.Values(key)
+	if len(values) == 0 {
+		t.Helper()
+		e.annotate(t).Errorf("expected header %s to be %q, but it is not set", key, expected)
+		return
+	}
+
+	if got := strings.Join(values, ", "); got != expected {
+		t.Helper()
+		e.annotate(t).Errorf("expected header %s to be %q, got %q", key, expected, got)
+	}
+}
+
+// HeaderMatches asserts that a header of the response is matched by a regular
+// expression, in the same way as assert.RegexMatches.
+func HeaderMatches[R Response](t testing.TB, r R, key, pattern string) {
+	e, err := newExchange(r)
+	if err != nil {
+		t.Helper()
+		t.Fatalf("%v", err)
+		return
+	}
+
+	values := e.res.Header.Values(key)
+	if len(values) == 0 {
+		t.Helper()
+		e.annotate(t).Errorf("expected header %s to match /%s/, but it is not set", key, pattern)
+		return
+	}
+
+	t.Helper()
+	assert.RegexMatches(e.annotate(t, assert.KV("header", key)), strings.Join(values, ", "), pattern)
+}
+
+// ContentType asserts that the media type of the response's Content-Type
+// header is the expected media type. Parameters, such as charset, are only
+// compared if the expected value includes them.
+func ContentType[R Response](t testing.TB, r R, expected string) {
+	e, err := newExchange(r)
+	if err != nil {
+		t.Helper()
+		t.Fatalf("%v", err)
+		return
+	}
+
+	expectedType, expectedParams, err := mime.ParseMediaType(expected)
+	if err != nil {
+		t.Helper()
+		t.Fatalf("invalid expected content type %q: %v", expected, err)
+		return
+	}
+
+	got := e.res.Header.Get("Content-Type")
+	gotType, gotParams, err := mime.ParseMediaType(got)
+	if err != nil {
+		t.Helper()
+		e.annotate(t).Errorf("expected content type %s, got %q", expected, got)
+		return
+	}
+
+	matches := gotType == expectedType
+	for k, v := range expectedParams {
+		matches = matches && strings.EqualFold(gotParams[k], v)
+	}
+
+	if !matches {
+		t.Helper()
+		e.annotate(t).Errorf("expected content type %s, got %s", expected, got)
+	}
+}
+
+// BodyEqual asserts that the body of the response is the expected string.
+// Multiline bodies that differ are reported as a unified diff.
+func BodyEqual[R Response](t testing.TB, r R, expected string) {
+	e, err := newExchange(r)
+	if err != nil {
+		t.Helper()
+		t.Fatalf("%v", err)
+		return
+	}
+
+	t.Helper()
+	assert.DeepEqual(e.annotate(t), string(e.body), expected)
+}
+
+// BodyJSONEqual asserts that the body of the response is a JSON document
+// semantically equivalent to the expected document, in the same way as
+// assert.JSONEqual.
+func BodyJSONEqual[R Response](t testing.TB, r R, expected any, opts ...assert.JSONOption) {
+	e, err := newExchange(r)
+	if err != nil {
+		t.Helper()
+		t.Fatalf("%v", err)
+		return
+	}
+
+	t.Helper()
+	assert.JSONEqual(e.annotate(t), e.body, expected, opts...)
+}
+
+// Redirects asserts that the response is a redirect to the expected location.
+func Redirects[R Response](t testing.TB, r R, location string) {
+	e, err := newExchange(r)
+	if err != nil {
+		t.Helper()
+		t.Fatalf("%v", err)
+		return
+	}
+
+	switch e.res.StatusCode {
+	case nethttp.StatusMovedPermanently, nethttp.StatusFound, nethttp.StatusSeeOther,
+		nethttp.StatusTemporaryRedirect, nethttp.StatusPermanentRedirect:
+	default:
+		t.Helper()
+		e.annotate(t).Errorf("expected redirect to %s, got status %s", location, statusText(e.res.StatusCode))
+		return
+	}
+
+	if got := e.res.Header.Get("Location"); got != location {
+		t.Helper()
+		e.annotate(t).Errorf("expected redirect to %s, got %s", location, got)
+	}
+}
+
+// SetsCookie asserts that the response sets a cookie with the given name and
+// returns it. Nil is returned if the cookie is not set.
+func SetsCookie[R Response](t testing.TB, r R, name string) *nethttp.Cookie {
+	e, err := newExchange(r)
+	if err != nil {
+		t.Helper()
+		t.Fatalf("%v", err)
+		return nil
+	}
+
+	cookies := e.res.Cookies()
+	names := make([]string, len(cookies))
+	for i, c := range cookies {
+		if c.Name == name {
+			return c
+		}
+		names[i] = c.Name
+	}
+
+	sort.Strings(names)
+
+	t.Helper()
+	if len(names) == 0 {
+		e.annotate(t).Errorf("expected cookie %s to be set, got no cookies", name)
+	} else {
+		e.annotate(t).Errorf("expected cookie %s to be set, got %s", name, strings.Join(names, ", "))
+	}
+	return nil
+}
+
+// exchange is a response and its body, along with the request that produced
+// it if it is known.
+type exchange struct {
+	res  *nethttp.Response
+	body []byte
+}
+
+// newExchange reads a response.
+func newExchange[R Response](r R) (*exchange, error) {
+	switch r := any(r).(type) {
+	case *httptest.ResponseRecorder:
+		if r == nil {
+			return nil, errors.New("response recorder is nil")
+		}
+
+		var body []byte
+		if r.Body != nil {
+			body = r.Body.Bytes()
+		}
+		return &exchange{res: r.Result(), body: body}, nil
+	case *nethttp.Response:
+		if r == nil {
+			return nil, errors.New("response is nil")
+		}
+
+		var body []byte
+		if r.Body != nil {
+			var err error
+			body, err = io.ReadAll(r.Body)
+			r.Body.Close()
+			if err != nil {
+				return nil, fmt.Errorf("failed to read response body: %w", err)
+			}
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		return &exchange{res: r, body: body}, nil
+	}
+	panic("unreachable")
+}
+
+// annotate wraps t so that failures include the request and response.
+func (e *exchange) annotate(t testing.TB, opts ...assert.Option) testing.TB {
+	return assert.With(t, append(opts, assert.Lazy(e.String))...)
+}
+
+// maxDumpBody is the number of bytes of a body included in failure messages.
+const maxDumpBody = 2048
+
+// String returns the request and response in wire format, with their bodies
+// truncated to maxDumpBody bytes.
+func (e *exchange) String() string {
+	var b strings.Builder
+
+	if req := e.res.Request; req != nil {
+		// Requests built by an http.Client when following redirects have no
+		// protocol version.
+		if req.ProtoMajor == 0 {
+			r := *req
+			r.ProtoMajor, r.ProtoMinor = 1, 1
+			req = &r
+		}
+
+		if dump, err := httputil.DumpRequest(req, false); err == nil {
+			var body []byte
+			if req.GetBody != nil {
+				if rc, err := req.GetBody(); err == nil {
+					body, _ = io.ReadAll(io.LimitReader(rc, maxDumpBody+1))
+					rc.Close()
+				}
+			}
+
+			b.WriteString("request:\n")
+			b.WriteString(indentWire(dump, body, -1))
+			b.WriteByte('\n')
+		}
+	}
+
+	res := *e.res
+	res.Body = nethttp.NoBody
+	dump, err := httputil.DumpResponse(&res, false)
+	if err != nil {
+		return b.String() + "response: " + err.Error()
+	}
+
+	b.WriteString("response:\n")
+	b.WriteString(indentWire(dump, e.body, len(e.body)))
+	return b.String()
+}
+
+// indentWire indents a dumped message and its body, which is truncated to
+// maxDumpBody bytes. If size is negative, the size of the body is unknown.
+func indentWire(head, body []byte, size int) string {
+	s := strings.TrimRight(strings.ReplaceAll(string(head), "\r\n", "\n"), "\n")
+
+	if len(body) > 0 {
+		s += "\n\n"
+		if len(body) <= maxDumpBody {
+			s += string(body)
+		} else {
+			// Avoid cutting a multibyte character in half.
+			n := maxDumpBody
+			for n > 0 && !utf8.RuneStart(body[n]) {
+				n--
+			}
+			s += string(body[:n])
+
+			if size < 0 {
+				s += "\n... (truncated)"
+			} else {
+				s += fmt.Sprintf("\n... (%d more bytes)", size-n)
+			}
+		}
+	}
+
+	return "\t" + strings.ReplaceAll(s, "\n", "\n\t")
+}
+
+// statusText formats a status code along with its text, such as 404 Not Found.
+func statusText(code int) string {
+	if text := nethttp.StatusText(code); text != "" {
+		return fmt.Sprintf("%d %s", code, text)
+	}
+	return fmt.Sprint(code)
+}
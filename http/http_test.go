@@ -0,0 +1,352 @@
+package http
+
+import (
+	"fmt"
+	"io"
+	nethttp "net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type mockTB struct {
+	*testing.T
+
+	ErrorfCalls []string
+	FatalfCalls []string
+	HelperCalls int
+}
+
+func newMockTB() *mockTB {
+	return &mockTB{T: &testing.T{}}
+}
+
+func (t *mockTB) Errorf(format string, args ...any) {
+	t.ErrorfCalls = append(t.ErrorfCalls, fmt.Sprintf(format, args...))
+}
+
+func (t *mockTB) Fatalf(format string, args ...any) {
+	t.FatalfCalls = append(t.FatalfCalls, fmt.Sprintf(format, args...))
+}
+
+func (t *mockTB) Helper() {
+	t.HelperCalls++
+}
+
+// record returns the response written by a handler.
+func record(h nethttp.HandlerFunc) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	h(rec, httptest.NewRequest("GET", "/", nil))
+	return rec
+}
+
+// firstLine returns the first line of a failure message, which excludes the
+// dumped request and response.
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
+
+var (
+	okHandler = func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Header().Add("Vary", "Accept")
+		w.Header().Add("Vary", "Origin")
+		nethttp.SetCookie(w, &nethttp.Cookie{Name: "session", Value: "abc"})
+		nethttp.SetCookie(w, &nethttp.Cookie{Name: "theme", Value: "dark"})
+		w.Write([]byte(`{"id": 1, "name": "alice"}`))
+	}
+	redirectHandler = func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		nethttp.Redirect(w, r, "/login", nethttp.StatusSeeOther)
+	}
+)
+
+func TestAssertions(t *testing.T) {
+	tests := []struct {
+		name            string
+		assert          func(t *mockTB, rec *httptest.ResponseRecorder)
+		expectedMessage string
+		expectFatal     bool
+	}{
+		{
+			name:   "StatusCode",
+			assert: func(t *mockTB, rec *httptest.ResponseRecorder) { StatusCode(t, rec, nethttp.StatusOK) },
+		},
+		{
+			name:            "StatusCode mismatch",
+			assert:          func(t *mockTB, rec *httptest.ResponseRecorder) { StatusCode(t, rec, nethttp.StatusCreated) },
+			expectedMessage: "expected status 201 Created, got 200 OK",
+		},
+		{
+			name:   "HeaderEqual with multiple values",
+			assert: func(t *mockTB, rec *httptest.ResponseRecorder) { HeaderEqual(t, rec, "vary", "Accept, Origin") },
+		},
+		{
+			name:            "HeaderEqual mismatch",
+			assert:          func(t *mockTB, rec *httptest.ResponseRecorder) { HeaderEqual(t, rec, "Vary", "Accept") },
+			expectedMessage: `expected header Vary to be "Accept", got "Accept, Origin"`,
+		},
+		{
+			name:            "HeaderEqual missing",
+			assert:          func(t *mockTB, rec *httptest.ResponseRecorder) { HeaderEqual(t, rec, "ETag", `"1"`) },
+			expectedMessage: `expected header ETag to be "\"1\"", but it is not set`,
+		},
+		{
+			name: "HeaderMatches",
+			assert: func(t *mockTB, rec *httptest.ResponseRecorder) {
+				HeaderMatches(t, rec, "Content-Type", `^application/json`)
+			},
+		},
+		{
+			name:            "HeaderMatches mismatch",
+			assert:          func(t *mockTB, rec *httptest.ResponseRecorder) { HeaderMatches(t, rec, "Content-Type", `^text/`) },
+			expectedMessage: "received string application/json; charset=utf-8 not matched by pattern /^text//",
+		},
+		{
+			name:   "ContentType without parameters",
+			assert: func(t *mockTB, rec *httptest.ResponseRecorder) { ContentType(t, rec, "application/json") },
+		},
+		{
+			name: "ContentType with parameters",
+			assert: func(t *mockTB, rec *httptest.ResponseRecorder) {
+				ContentType(t, rec, "application/json; charset=UTF-8")
+			},
+		},
+		{
+			name:            "ContentType mismatch",
+			assert:          func(t *mockTB, rec *httptest.ResponseRecorder) { ContentType(t, rec, "text/html") },
+			expectedMessage: "expected content type text/html, got application/json; charset=utf-8",
+		},
+		{
+			name:        "ContentType invalid",
+			assert:      func(t *mockTB, rec *httptest.ResponseRecorder) { ContentType(t, rec, "text/") },
+			expectFatal: true,
+		},
+		{
+			name:   "BodyEqual",
+			assert: func(t *mockTB, rec *httptest.ResponseRecorder) { BodyEqual(t, rec, `{"id": 1, "name": "alice"}`) },
+		},
+		{
+			name:            "BodyEqual mismatch",
+			assert:          func(t *mockTB, rec *httptest.ResponseRecorder) { BodyEqual(t, rec, `{}`) },
+			expectedMessage: "values are not equal:",
+		},
+		{
+			name: "BodyJSONEqual",
+			assert: func(t *mockTB, rec *httptest.ResponseRecorder) {
+				BodyJSONEqual(t, rec, map[string]any{"name": "alice", "id": 1})
+			},
+		},
+		{
+			name:            "BodyJSONEqual mismatch",
+			assert:          func(t *mockTB, rec *httptest.ResponseRecorder) { BodyJSONEqual(t, rec, `{"id": 2, "name": "alice"}`) },
+			expectedMessage: "JSON documents are not equal:",
+		},
+		{
+			name:            "Redirects without redirect",
+			assert:          func(t *mockTB, rec *httptest.ResponseRecorder) { Redirects(t, rec, "/login") },
+			expectedMessage: "expected redirect to /login, got status 200 OK",
+		},
+		{
+			name: "SetsCookie",
+			assert: func(t *mockTB, rec *httptest.ResponseRecorder) {
+				if c := SetsCookie(t, rec, "theme"); c == nil || c.Value != "dark" {
+					t.T.Errorf("expected theme cookie, got %v", c)
+				}
+			},
+		},
+		{
+			name: "SetsCookie missing",
+			assert: func(t *mockTB, rec *httptest.ResponseRecorder) {
+				if c := SetsCookie(t, rec, "user"); c != nil {
+					t.T.Errorf("expected nil cookie, got %v", c)
+				}
+			},
+			expectedMessage: "expected cookie user to be set, got session, theme",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+			mockT.T = t
+
+			tt.assert(mockT, record(okHandler))
+
+			expectedErrors, expectedFatals := 0, 0
+			if tt.expectFatal {
+				expectedFatals = 1
+			} else if tt.expectedMessage != "" {
+				expectedErrors = 1
+			}
+
+			if len(mockT.ErrorfCalls) != expectedErrors {
+				t.Fatalf("expected %d calls to Errorf(), got %d", expectedErrors, len(mockT.ErrorfCalls))
+			}
+
+			if len(mockT.FatalfCalls) != expectedFatals {
+				t.Fatalf("expected %d calls to Fatalf(), got %d", expectedFatals, len(mockT.FatalfCalls))
+			}
+
+			if expectedErrors+expectedFatals > 0 && mockT.HelperCalls == 0 {
+				t.Errorf("expected calls to Helper()")
+			}
+
+			if expectedErrors == 0 {
+				return
+			}
+
+			msg := mockT.ErrorfCalls[0]
+			if got := firstLine(msg); got != tt.expectedMessage {
+				t.Errorf("expected message %q, got %q", tt.expectedMessage, got)
+			}
+
+			if !strings.Contains(msg, "\nresponse:\n\tHTTP/1.1 200 OK\n") {
+				t.Errorf("expected message to include the response, got:\n%s", msg)
+			}
+		})
+	}
+}
+
+func TestRedirects(t *testing.T) {
+	tests := []struct {
+		name            string
+		location        string
+		expectedMessage string
+	}{
+		{name: "Matching location", location: "/login"},
+		{name: "Differing location", location: "/", expectedMessage: "expected redirect to /, got /login"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+
+			Redirects(mockT, record(redirectHandler), tt.location)
+
+			if tt.expectedMessage == "" {
+				if len(mockT.ErrorfCalls) != 0 {
+					t.Fatalf("expected 0 calls to Errorf(), got %d", len(mockT.ErrorfCalls))
+				}
+				return
+			}
+
+			if len(mockT.ErrorfCalls) != 1 {
+				t.Fatalf("expected 1 call to Errorf(), got %d", len(mockT.ErrorfCalls))
+			}
+
+			if got := firstLine(mockT.ErrorfCalls[0]); got != tt.expectedMessage {
+				t.Errorf("expected message %q, got %q", tt.expectedMessage, got)
+			}
+		})
+	}
+}
+
+func TestResponse(t *testing.T) {
+	req, err := nethttp.NewRequest("POST", "http://example.com/users?x=1", strings.NewReader(`{"name": "bob"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res := &nethttp.Response{
+		Status:        "409 Conflict",
+		StatusCode:    nethttp.StatusConflict,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		ContentLength: 11,
+		Header:        nethttp.Header{"Content-Type": {"text/plain"}},
+		Body:          io.NopCloser(strings.NewReader("user exists")),
+		Request:       req,
+	}
+
+	mockT := newMockTB()
+	StatusCode(mockT, res, nethttp.StatusCreated)
+	BodyEqual(mockT, res, "user exists")
+
+	if len(mockT.ErrorfCalls) != 1 {
+		t.Fatalf("expected 1 call to Errorf(), got %d", len(mockT.ErrorfCalls))
+	}
+
+	expected := "expected status 201 Created, got 409 Conflict\n" +
+		"request:\n" +
+		"\tPOST /users?x=1 HTTP/1.1\n" +
+		"\tHost: example.com\n" +
+		"\tContent-Type: application/json\n" +
+		"\t\n" +
+		"\t{\"name\": \"bob\"}\n" +
+		"response:\n" +
+		"\tHTTP/1.1 409 Conflict\n" +
+		"\tContent-Length: 11\n" +
+		"\tContent-Type: text/plain\n" +
+		"\t\n" +
+		"\tuser exists"
+	if mockT.ErrorfCalls[0] != expected {
+		t.Errorf("expected message:\n%s\ngot:\n%s", expected, mockT.ErrorfCalls[0])
+	}
+
+	// The body is still readable after the assertions.
+	if b, _ := io.ReadAll(res.Body); string(b) != "user exists" {
+		t.Errorf("expected body to be readable, got %q", b)
+	}
+}
+
+func TestWithRequest(t *testing.T) {
+	req := httptest.NewRequest("DELETE", "/users/1", nil)
+	rec := httptest.NewRecorder()
+	nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		w.WriteHeader(nethttp.StatusNoContent)
+	}).ServeHTTP(rec, req)
+
+	mockT := newMockTB()
+	StatusCode(mockT, rec, nethttp.StatusOK)
+	StatusCode(mockT, WithRequest(rec, req), nethttp.StatusOK)
+
+	if len(mockT.ErrorfCalls) != 2 {
+		t.Fatalf("expected 2 calls to Errorf(), got %d", len(mockT.ErrorfCalls))
+	}
+
+	response := "response:\n" +
+		"\tHTTP/1.1 204 No Content\n" +
+		"\tConnection: close"
+	expected := "expected status 200 OK, got 204 No Content\n" + response
+	if mockT.ErrorfCalls[0] != expected {
+		t.Errorf("expected message:\n%s\ngot:\n%s", expected, mockT.ErrorfCalls[0])
+	}
+
+	expected = "expected status 200 OK, got 204 No Content\n" +
+		"request:\n" +
+		"\tDELETE /users/1 HTTP/1.1\n" +
+		"\tHost: example.com\n" +
+		response
+	if mockT.ErrorfCalls[1] != expected {
+		t.Errorf("expected message:\n%s\ngot:\n%s", expected, mockT.ErrorfCalls[1])
+	}
+}
+
+func TestTruncatedBody(t *testing.T) {
+	rec := record(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte(strings.Repeat("a", maxDumpBody-1) + "é" + strings.Repeat("b", 100)))
+	})
+
+	mockT := newMockTB()
+	StatusCode(mockT, rec, nethttp.StatusNotFound)
+
+	if len(mockT.ErrorfCalls) != 1 {
+		t.Fatalf("expected 1 call to Errorf(), got %d", len(mockT.ErrorfCalls))
+	}
+
+	suffix := "\n\t" + strings.Repeat("a", maxDumpBody-1) + "\n\t... (102 more bytes)"
+	if msg := mockT.ErrorfCalls[0]; !strings.HasSuffix(msg, suffix) {
+		t.Errorf("expected message to end with truncated body, got:\n%s", msg[len(msg)-200:])
+	}
+}
+
+func TestNilResponse(t *testing.T) {
+	mockT := newMockTB()
+	StatusCode(mockT, (*nethttp.Response)(nil), nethttp.StatusOK)
+
+	if len(mockT.FatalfCalls) != 1 || mockT.FatalfCalls[0] != "response is nil" {
+		t.Errorf("expected fatal failure for nil response, got %q", mockT.FatalfCalls)
+	}
+}
@@ -0,0 +1,342 @@
+package assert
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// JSONOption configures how JSON documents are compared.
+type JSONOption func(*jsonConfig)
+
+// jsonConfig holds the comparison settings of the JSON assertions.
+type jsonConfig struct {
+	tolerance *big.Rat
+	// subset ignores object members that are only present in the actual
+	// document.
+	subset bool
+}
+
+// JSONTolerance allows numbers to differ by up to delta and still be
+// considered equal.
+func JSONTolerance(delta float64) JSONOption {
+	return func(c *jsonConfig) {
+		c.tolerance = new(big.Rat).SetFloat64(delta)
+	}
+}
+
+func newJSONConfig(opts []JSONOption) *jsonConfig {
+	c := &jsonConfig{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// JSONEqual asserts that two JSON documents are semantically equivalent,
+// ignoring formatting and the order of object keys. Documents may be provided
+// as a string, []byte, or json.RawMessage holding JSON text, or as any other
+// value, which is marshaled with encoding/json. Numbers are compared exactly,
+// so large integers do not lose precision, unless a JSONTolerance is provided.
+// Differences are reported by their JSON path.
+func JSONEqual(t testing.TB, got, expected any, opts ...JSONOption) {
+	e, err := parseJSON(expected)
+	if err != nil {
+		t.Helper()
+		t.Fatalf("failed to parse expected JSON: %v", err)
+		return
+	}
+
+	g, err := parseJSON(got)
+	if err != nil {
+		t.Helper()
+		t.Errorf("failed to parse JSON: %v", err)
+		return
+	}
+
+	c := newJSONConfig(opts)
+	var diffs []difference
+	c.diff(&diffs, "$", e, g)
+
+	if len(diffs) > 0 {
+		t.Helper()
+		t.Errorf("JSON documents are not equal:\n%s", indent(joinDifferences(diffs), "\t"))
+	}
+}
+
+// JSONContains asserts that the expected JSON document is a structural subset
+// of the actual document. Every member of an expected object must be present
+// in the actual object, which may contain other members as well. Arrays must
+// have the same length, with each element of the actual array containing the
+// corresponding expected element. Documents are provided and compared in the
+// same way as JSONEqual.
+func JSONContains(t testing.TB, got, expected any, opts ...JSONOption) {
+	e, err := parseJSON(expected)
+	if err != nil {
+		t.Helper()
+		t.Fatalf("failed to parse expected JSON: %v", err)
+		return
+	}
+
+	g, err := parseJSON(got)
+	if err != nil {
+		t.Helper()
+		t.Errorf("failed to parse JSON: %v", err)
+		return
+	}
+
+	c := newJSONConfig(opts)
+	c.subset = true
+	var diffs []difference
+	c.diff(&diffs, "$", e, g)
+
+	if len(diffs) > 0 {
+		t.Helper()
+		t.Errorf("JSON document does not contain expected document:\n%s", indent(joinDifferences(diffs), "\t"))
+	}
+}
+
+// JSONPathEqual asserts that the value selected from a JSON document by a
+// JSONPath expression is equal to the expected value. The document is provided
+// in the same way as JSONEqual, while the expected value is always marshaled
+// with encoding/json, so strings are compared as JSON strings. Use a
+// json.RawMessage to provide the expected value as JSON text.
+//
+// Paths that can select more than one node, such as those with wildcards,
+// slices, filters, or recursive descent, are compared as an array of every
+// selected node in document order.
+//
+// The supported syntax is:
+//
+//	$              the root of the document
+//	.name ['name'] a member of an object
+//	.* [*]         every member of an object or element of an array
+//	..name ..*     recursive descent
+//	[n]            an element of an array, negative indexes count from the end
+//	[start:end]    a slice of an array
+//	[?(expr)]      the members or elements matching a filter expression
+//
+// Filter expressions compare paths relative to the filtered node, starting
+// with @, against literals or other paths with ==, !=, <, <=, >, and >=. A
+// path on its own tests for existence. Conditions may be combined with && and
+// ||, grouped with parentheses, and negated with !. For example,
+// $.users[?(@.age >= 18 && @.name != 'bob')].name.
+func JSONPathEqual(t testing.TB, doc any, path string, expected any, opts ...JSONOption) {
+	p, err := parseJSONPath(path)
+	if err != nil {
+		t.Helper()
+		t.Fatalf("%v", err)
+		return
+	}
+
+	e, err := marshalJSON(expected)
+	if err != nil {
+		t.Helper()
+		t.Fatalf("failed to marshal expected value: %v", err)
+		return
+	}
+
+	d, err := parseJSON(doc)
+	if err != nil {
+		t.Helper()
+		t.Errorf("failed to parse JSON: %v", err)
+		return
+	}
+
+	nodes := p.evaluate(d)
+
+	var got any
+	if p.definite() {
+		if len(nodes) == 0 {
+			prefix, node := p.longestMatch(d)
+			t.Helper()
+			t.Errorf("JSON path %s not found, %s is %s", path, prefix, jsonString(node))
+			return
+		}
+		got = nodes[0]
+	} else {
+		got = append([]any{}, nodes...)
+	}
+
+	c := newJSONConfig(opts)
+	var diffs []difference
+	c.diff(&diffs, path, e, got)
+
+	if len(diffs) > 0 {
+		t.Helper()
+		t.Errorf("JSON path %s is not equal:\n%s", path, indent(joinDifferences(diffs), "\t"))
+	}
+}
+
+// marshalJSON converts any value into a generic JSON tree by marshaling it.
+func marshalJSON(v any) (any, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return parseJSON(b)
+}
+
+// parseJSON decodes a JSON document into a generic tree of map[string]any,
+// []any, json.Number, string, bool, and nil values.
+func parseJSON(v any) (any, error) {
+	var data []byte
+	switch v := v.(type) {
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	case json.RawMessage:
+		data = v
+	default:
+		var err error
+		if data, err = json.Marshal(v); err != nil {
+			return nil, err
+		}
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var doc any
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return nil, errors.New("unexpected data after top-level value")
+	}
+
+	return doc, nil
+}
+
+// diff records every path at which got differs from expected.
+func (c *jsonConfig) diff(diffs *[]difference, path string, expected, got any) {
+	switch e := expected.(type) {
+	case map[string]any:
+		g, ok := got.(map[string]any)
+		if !ok {
+			break
+		}
+
+		for _, k := range sortedKeys(e) {
+			gv, ok := g[k]
+			if !ok {
+				*diffs = append(*diffs, difference{path: jsonPathKey(path, k), detail: "missing key with value " + jsonString(e[k])})
+				continue
+			}
+			c.diff(diffs, jsonPathKey(path, k), e[k], gv)
+		}
+
+		for _, k := range sortedKeys(g) {
+			if _, ok := e[k]; !ok && !c.subset {
+				*diffs = append(*diffs, difference{path: jsonPathKey(path, k), detail: "unexpected key with value " + jsonString(g[k])})
+			}
+		}
+		return
+	case []any:
+		g, ok := got.([]any)
+		if !ok {
+			break
+		}
+
+		for i := 0; i < len(e) || i < len(g); i++ {
+			p := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case i >= len(g):
+				*diffs = append(*diffs, difference{path: p, detail: "missing element " + jsonString(e[i])})
+			case i >= len(e):
+				*diffs = append(*diffs, difference{path: p, detail: "unexpected element " + jsonString(g[i])})
+			default:
+				c.diff(diffs, p, e[i], g[i])
+			}
+		}
+		return
+	case json.Number:
+		if g, ok := got.(json.Number); ok && c.numbersEqual(e, g) {
+			return
+		}
+	default:
+		if expected == got {
+			return
+		}
+	}
+
+	*diffs = append(*diffs, difference{path: path, expected: jsonString(expected), got: jsonString(got)})
+}
+
+// numbersEqual reports whether two JSON numbers are equal, or within the
+// configured tolerance of each other. Numbers are compared as exact rationals.
+func (c *jsonConfig) numbersEqual(a, b json.Number) bool {
+	if a == b {
+		return true
+	}
+
+	ra, ok := new(big.Rat).SetString(string(a))
+	if !ok {
+		return false
+	}
+
+	rb, ok := new(big.Rat).SetString(string(b))
+	if !ok {
+		return false
+	}
+
+	if c.tolerance == nil {
+		return ra.Cmp(rb) == 0
+	}
+
+	delta := new(big.Rat).Sub(ra, rb)
+	return delta.Abs(delta).Cmp(c.tolerance) <= 0
+}
+
+// jsonIdentifier matches object keys that can be written in dot notation.
+var jsonIdentifier = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// jsonPathKey appends an object key to a JSON path.
+func jsonPathKey(path, key string) string {
+	if jsonIdentifier.MatchString(key) {
+		return path + "." + key
+	}
+	return path + "[" + quoteJSONPathKey(key) + "]"
+}
+
+// quoteJSONPathKey quotes a key for use in bracket notation.
+func quoteJSONPathKey(key string) string {
+	return "'" + strings.ReplaceAll(strings.ReplaceAll(key, `\`, `\\`), "'", `\'`) + "'"
+}
+
+// jsonString renders a value of a generic JSON tree as compact JSON.
+func jsonString(v any) string {
+	if v == nil {
+		return "null"
+	}
+
+	if s, ok := v.(string); ok {
+		return strconv.Quote(s)
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
+
+// sortedKeys returns the keys of a map in sorted order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,285 @@
+package assert
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestJSONEqual(t *testing.T) {
+	tests := []struct {
+		name               string
+		got                any
+		expected           any
+		opts               []JSONOption
+		expectedMessage    string
+		expectedErrorCalls int
+		expectedFatalCalls int
+	}{
+		{
+			name:     "Formatting and key order",
+			got:      `{"b": [1, 2], "a": {"c": null}}`,
+			expected: "{\n\t\"a\": {\"c\": null},\n\t\"b\": [1,2]\n}",
+		},
+		{
+			name:     "Bytes and raw messages",
+			got:      []byte(`{"a": 1}`),
+			expected: json.RawMessage(`{"a":1}`),
+		},
+		{
+			name:     "Marshaled values",
+			got:      `{"name": "alice", "tags": ["x"]}`,
+			expected: map[string]any{"name": "alice", "tags": []string{"x"}},
+		},
+		{
+			name:     "Equivalent numbers",
+			got:      `[1.0, 100, 0.5]`,
+			expected: `[1, 1e2, 5e-1]`,
+		},
+		{
+			name:               "Large integers",
+			got:                `{"id": 9007199254740993}`,
+			expected:           `{"id": 9007199254740992}`,
+			expectedMessage:    "JSON documents are not equal:\n\t$.id: expected 9007199254740992, got 9007199254740993",
+			expectedErrorCalls: 1,
+		},
+		{
+			name:     "Within tolerance",
+			got:      `{"price": 12.051}`,
+			expected: `{"price": 12.05}`,
+			opts:     []JSONOption{JSONTolerance(0.01)},
+		},
+		{
+			name:               "Outside tolerance",
+			got:                `{"price": 12.5}`,
+			expected:           `{"price": 12.05}`,
+			opts:               []JSONOption{JSONTolerance(0.01)},
+			expectedErrorCalls: 1,
+		},
+		{
+			name:     "Nested differences",
+			got:      `{"items": [{"id": 1}, {"id": 2}, {"id": 4, "x": true}], "my key": "a"}`,
+			expected: `{"items": [{"id": 1}, {"id": 2}, {"id": 3}, {"id": 5}], "my key": "b"}`,
+			expectedMessage: "JSON documents are not equal:\n" +
+				"\t$.items[2].id: expected 3, got 4\n" +
+				"\t$.items[2].x: unexpected key with value true\n" +
+				"\t$.items[3]: missing element {\"id\":5}\n" +
+				"\t$['my key']: expected \"b\", got \"a\"",
+			expectedErrorCalls: 1,
+		},
+		{
+			name:               "Type mismatch",
+			got:                `{"a": "1"}`,
+			expected:           `{"a": 1}`,
+			expectedMessage:    "JSON documents are not equal:\n\t$.a: expected 1, got \"1\"",
+			expectedErrorCalls: 1,
+		},
+		{
+			name:               "Invalid got",
+			got:                `{"a": `,
+			expected:           `{"a": 1}`,
+			expectedErrorCalls: 1,
+		},
+		{
+			name:               "Trailing data",
+			got:                `{"a": 1} {}`,
+			expected:           `{"a": 1}`,
+			expectedErrorCalls: 1,
+		},
+		{
+			name:               "Invalid expected",
+			got:                `{"a": 1}`,
+			expected:           `{"a"`,
+			expectedFatalCalls: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+
+			JSONEqual(mockT, tt.got, tt.expected, tt.opts...)
+			n := len(mockT.ErrorfCalls)
+			m := len(mockT.FatalfCalls)
+
+			if n != tt.expectedErrorCalls {
+				t.Fatalf("expected %d calls to Errorf(), got %d", tt.expectedErrorCalls, n)
+			}
+
+			if m != tt.expectedFatalCalls {
+				t.Errorf("expected %d calls to Fatalf(), got %d", tt.expectedFatalCalls, m)
+			}
+
+			if mockT.HelperCalls != n+m {
+				t.Errorf("expected %d calls to Helper(), got %d", n+m, mockT.HelperCalls)
+			}
+
+			if tt.expectedMessage != "" && mockT.ErrorfCalls[0].message() != tt.expectedMessage {
+				t.Errorf("expected message:\n%s\ngot:\n%s", tt.expectedMessage, mockT.ErrorfCalls[0].message())
+			}
+		})
+	}
+}
+
+func TestJSONContains(t *testing.T) {
+	tests := []struct {
+		name            string
+		got             any
+		expected        any
+		expectedMessage string
+		expectedCalls   int
+	}{
+		{
+			name:     "Subset of object",
+			got:      `{"id": 1, "name": "alice", "meta": {"created": "today", "tags": ["a"]}}`,
+			expected: `{"name": "alice", "meta": {"tags": ["a"]}}`,
+		},
+		{
+			name:     "Subset within arrays",
+			got:      `[{"id": 1, "x": true}, {"id": 2, "x": false}]`,
+			expected: `[{"id": 1}, {"id": 2}]`,
+		},
+		{
+			name:            "Missing member",
+			got:             `{"id": 1, "meta": {}}`,
+			expected:        `{"meta": {"tags": []}}`,
+			expectedMessage: "JSON document does not contain expected document:\n\t$.meta.tags: missing key with value []",
+			expectedCalls:   1,
+		},
+		{
+			name:          "Different value",
+			got:           `{"id": 1}`,
+			expected:      `{"id": 2}`,
+			expectedCalls: 1,
+		},
+		{
+			name:          "Array length",
+			got:           `[1, 2, 3]`,
+			expected:      `[1, 2]`,
+			expectedCalls: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+
+			JSONContains(mockT, tt.got, tt.expected)
+			n := len(mockT.ErrorfCalls)
+
+			if n != tt.expectedCalls {
+				t.Fatalf("expected %d calls to Errorf(), got %d", tt.expectedCalls, n)
+			}
+
+			if n != mockT.HelperCalls {
+				t.Errorf("expected %d calls to Helper(), got %d", tt.expectedCalls, mockT.HelperCalls)
+			}
+
+			if tt.expectedMessage != "" && mockT.ErrorfCalls[0].message() != tt.expectedMessage {
+				t.Errorf("expected message:\n%s\ngot:\n%s", tt.expectedMessage, mockT.ErrorfCalls[0].message())
+			}
+		})
+	}
+}
+
+func TestJSONPathEqual(t *testing.T) {
+	doc := `{
+		"data": {
+			"users": [
+				{"name": "alice", "age": 30, "admin": true},
+				{"name": "bob", "age": 17},
+				{"name": "carol", "age": 45, "email": "carol@example.com"}
+			],
+			"my key": 1
+		}
+	}`
+
+	tests := []struct {
+		name               string
+		path               string
+		expected           any
+		expectedMessage    string
+		expectedErrorCalls int
+		expectedFatalCalls int
+	}{
+		{name: "Member and index", path: "$.data.users[0].name", expected: "alice"},
+		{name: "Bracket member", path: "$['data']['my key']", expected: 1},
+		{name: "Negative index", path: "$.data.users[-1].name", expected: "carol"},
+		{name: "Wildcard", path: "$.data.users[*].name", expected: []string{"alice", "bob", "carol"}},
+		{name: "Slice", path: "$.data.users[1:].age", expected: []int{17, 45}},
+		{name: "Recursive descent", path: "$..email", expected: []string{"carol@example.com"}},
+		{name: "Filter comparison", path: "$.data.users[?(@.age >= 18)].name", expected: []string{"alice", "carol"}},
+		{name: "Filter existence", path: "$.data.users[?(@.admin)].name", expected: []string{"alice"}},
+		{name: "Filter logic", path: "$.data.users[?(@.age < 40 && !(@.name == 'alice'))].name", expected: []string{"bob"}},
+		{name: "Filter or", path: `$.data.users[?(@.name == "bob" || @.age > 40)].age`, expected: []int{17, 45}},
+		{name: "Object value", path: "$.data.users[1]", expected: map[string]any{"name": "bob", "age": 17}},
+		{name: "Raw expected", path: "$.data.users[0].age", expected: json.RawMessage(`30.0`)},
+		{
+			name:               "Different value",
+			path:               "$.data.users[0].name",
+			expected:           "bob",
+			expectedMessage:    "JSON path $.data.users[0].name is not equal:\n\t$.data.users[0].name: expected \"bob\", got \"alice\"",
+			expectedErrorCalls: 1,
+		},
+		{
+			name:               "Not found",
+			path:               "$.data.users[1].email",
+			expected:           "bob@example.com",
+			expectedMessage:    `JSON path $.data.users[1].email not found, $.data.users[1] is {"age":17,"name":"bob"}`,
+			expectedErrorCalls: 1,
+		},
+		{
+			name:               "Nothing matched",
+			path:               "$.data.users[?(@.age > 100)].name",
+			expected:           []string{"dave"},
+			expectedErrorCalls: 1,
+		},
+		{
+			name:               "Invalid path",
+			path:               "$.data.users[",
+			expected:           nil,
+			expectedFatalCalls: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+
+			JSONPathEqual(mockT, doc, tt.path, tt.expected)
+			n := len(mockT.ErrorfCalls)
+			m := len(mockT.FatalfCalls)
+
+			if n != tt.expectedErrorCalls {
+				t.Fatalf("expected %d calls to Errorf(), got %d", tt.expectedErrorCalls, n)
+			}
+
+			if m != tt.expectedFatalCalls {
+				t.Errorf("expected %d calls to Fatalf(), got %d", tt.expectedFatalCalls, m)
+			}
+
+			if mockT.HelperCalls != n+m {
+				t.Errorf("expected %d calls to Helper(), got %d", n+m, mockT.HelperCalls)
+			}
+
+			if tt.expectedMessage != "" && mockT.ErrorfCalls[0].message() != tt.expectedMessage {
+				t.Errorf("expected message:\n%s\ngot:\n%s", tt.expectedMessage, mockT.ErrorfCalls[0].message())
+			}
+		})
+	}
+}
+
+func TestParseJSONPathErrors(t *testing.T) {
+	tests := []string{
+		"data",
+		"$.",
+		"$[",
+		"$['name",
+		"$[?(@.a ==)]",
+		"$[?(1)]",
+		"$.a]",
+	}
+	for _, path := range tests {
+		t.Run(path, func(t *testing.T) {
+			if _, err := parseJSONPath(path); err == nil {
+				t.Errorf("expected error parsing %q, got nil", path)
+			}
+		})
+	}
+}
@@ -0,0 +1,641 @@
+package assert
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// jsonPath is a compiled JSONPath expression. See JSONPathEqual for the
+// supported syntax.
+type jsonPath struct {
+	segments []jsonPathSegment
+}
+
+// jsonPathSegmentKind identifies how a segment selects nodes.
+type jsonPathSegmentKind int
+
+const (
+	jsonPathMember jsonPathSegmentKind = iota
+	jsonPathWildcard
+	jsonPathIndex
+	jsonPathSlice
+	jsonPathFilter
+)
+
+// jsonPathSegment is a single step of a JSONPath expression.
+type jsonPathSegment struct {
+	// src is the text of the segment in the expression.
+	src       string
+	kind      jsonPathSegmentKind
+	recursive bool
+	name      string
+	index     int
+	// start and end bound a slice. A nil bound is open.
+	start, end *int
+	filter     jsonFilter
+}
+
+// definite reports whether the path selects at most one node.
+func (p *jsonPath) definite() bool {
+	for _, s := range p.segments {
+		if s.recursive || (s.kind != jsonPathMember && s.kind != jsonPathIndex) {
+			return false
+		}
+	}
+	return true
+}
+
+// evaluate returns the nodes of the document selected by the path.
+func (p *jsonPath) evaluate(doc any) []any {
+	return evaluateSegments(p.segments, doc)
+}
+
+// longestMatch returns the longest prefix of a definite path that selects a
+// node from the document, along with that node.
+func (p *jsonPath) longestMatch(doc any) (string, any) {
+	prefix, node := "$", doc
+	for i, s := range p.segments {
+		nodes := evaluateSegments(p.segments[i:i+1], node)
+		if len(nodes) != 1 {
+			break
+		}
+		prefix, node = prefix+s.src, nodes[0]
+	}
+	return prefix, node
+}
+
+// evaluateSegments applies each segment in turn, starting from the document.
+func evaluateSegments(segments []jsonPathSegment, doc any) []any {
+	nodes := []any{doc}
+	for _, s := range segments {
+		var next []any
+		for _, n := range nodes {
+			if s.recursive {
+				for _, d := range descendants(n) {
+					next = append(next, s.selectFrom(d)...)
+				}
+			} else {
+				next = append(next, s.selectFrom(n)...)
+			}
+		}
+		nodes = next
+	}
+	return nodes
+}
+
+// descendants returns a node followed by all of its descendants, in document
+// order with object members sorted by key.
+func descendants(n any) []any {
+	nodes := []any{n}
+	for _, c := range children(n) {
+		nodes = append(nodes, descendants(c)...)
+	}
+	return nodes
+}
+
+// children returns the members of an object, sorted by key, or the elements
+// of an array.
+func children(n any) []any {
+	switch n := n.(type) {
+	case map[string]any:
+		c := make([]any, 0, len(n))
+		for _, k := range sortedKeys(n) {
+			c = append(c, n[k])
+		}
+		return c
+	case []any:
+		return n
+	}
+	return nil
+}
+
+// selectFrom returns the nodes selected by the segment from a single node.
+func (s *jsonPathSegment) selectFrom(n any) []any {
+	switch s.kind {
+	case jsonPathMember:
+		if obj, ok := n.(map[string]any); ok {
+			if v, ok := obj[s.name]; ok {
+				return []any{v}
+			}
+		}
+	case jsonPathWildcard:
+		return children(n)
+	case jsonPathIndex:
+		if arr, ok := n.([]any); ok {
+			i := s.index
+			if i < 0 {
+				i += len(arr)
+			}
+			if i >= 0 && i < len(arr) {
+				return []any{arr[i]}
+			}
+		}
+	case jsonPathSlice:
+		if arr, ok := n.([]any); ok {
+			start, end := 0, len(arr)
+			if s.start != nil {
+				start = clampIndex(*s.start, len(arr))
+			}
+			if s.end != nil {
+				end = clampIndex(*s.end, len(arr))
+			}
+			if start < end {
+				return arr[start:end]
+			}
+		}
+	case jsonPathFilter:
+		var matched []any
+		for _, c := range children(n) {
+			if s.filter.match(c) {
+				matched = append(matched, c)
+			}
+		}
+		return matched
+	}
+	return nil
+}
+
+// clampIndex resolves a possibly negative slice bound against an array length.
+func clampIndex(i, n int) int {
+	if i < 0 {
+		i += n
+	}
+	if i < 0 {
+		return 0
+	}
+	if i > n {
+		return n
+	}
+	return i
+}
+
+// jsonFilter is a compiled filter expression.
+type jsonFilter interface {
+	match(n any) bool
+}
+
+// jsonFilterOr matches if either side matches.
+type jsonFilterOr struct{ left, right jsonFilter }
+
+func (f jsonFilterOr) match(n any) bool { return f.left.match(n) || f.right.match(n) }
+
+// jsonFilterAnd matches if both sides match.
+type jsonFilterAnd struct{ left, right jsonFilter }
+
+func (f jsonFilterAnd) match(n any) bool { return f.left.match(n) && f.right.match(n) }
+
+// jsonFilterNot matches if the negated filter does not.
+type jsonFilterNot struct{ filter jsonFilter }
+
+func (f jsonFilterNot) match(n any) bool { return !f.filter.match(n) }
+
+// jsonFilterExists matches nodes for which a relative path selects a node.
+type jsonFilterExists struct{ path *jsonPath }
+
+func (f jsonFilterExists) match(n any) bool { return len(f.path.evaluate(n)) > 0 }
+
+// jsonFilterCompare matches nodes for which a comparison holds.
+type jsonFilterCompare struct {
+	op          string
+	left, right jsonOperand
+}
+
+func (f jsonFilterCompare) match(n any) bool {
+	l, ok := f.left.resolve(n)
+	if !ok {
+		return false
+	}
+
+	r, ok := f.right.resolve(n)
+	if !ok {
+		return false
+	}
+
+	switch f.op {
+	case "==":
+		return jsonValuesEqual(l, r)
+	case "!=":
+		return !jsonValuesEqual(l, r)
+	}
+
+	cmp, ok := compareJSONValues(l, r)
+	if !ok {
+		return false
+	}
+
+	switch f.op {
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	default:
+		return cmp >= 0
+	}
+}
+
+// jsonOperand is either a literal or a path relative to the filtered node.
+type jsonOperand struct {
+	path    *jsonPath
+	literal any
+}
+
+// resolve returns the value of the operand for a node.
+func (o jsonOperand) resolve(n any) (any, bool) {
+	if o.path == nil {
+		return o.literal, true
+	}
+
+	nodes := o.path.evaluate(n)
+	if len(nodes) != 1 {
+		return nil, false
+	}
+	return nodes[0], true
+}
+
+// jsonValuesEqual reports whether two generic JSON values are equivalent.
+func jsonValuesEqual(a, b any) bool {
+	var diffs []difference
+	(&jsonConfig{}).diff(&diffs, "", a, b)
+	return len(diffs) == 0
+}
+
+// compareJSONValues orders two numbers or two strings.
+func compareJSONValues(a, b any) (int, bool) {
+	switch a := a.(type) {
+	case json.Number:
+		b, ok := b.(json.Number)
+		if !ok {
+			return 0, false
+		}
+
+		ra, ok1 := new(big.Rat).SetString(string(a))
+		rb, ok2 := new(big.Rat).SetString(string(b))
+		if !ok1 || !ok2 {
+			return 0, false
+		}
+		return ra.Cmp(rb), true
+	case string:
+		b, ok := b.(string)
+		if !ok {
+			return 0, false
+		}
+		return strings.Compare(a, b), true
+	}
+	return 0, false
+}
+
+// jsonPathParser parses JSONPath expressions.
+type jsonPathParser struct {
+	expr string
+	pos  int
+}
+
+// parseJSONPath compiles a JSONPath expression starting with $.
+func parseJSONPath(expr string) (*jsonPath, error) {
+	p := &jsonPathParser{expr: expr}
+	path, err := p.parsePath('$')
+	if err != nil {
+		return nil, err
+	}
+
+	if p.pos != len(p.expr) {
+		return nil, p.errorf("unexpected %q", p.expr[p.pos:])
+	}
+	return path, nil
+}
+
+func (p *jsonPathParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("invalid JSON path %q at offset %d: %s", p.expr, p.pos, fmt.Sprintf(format, args...))
+}
+
+func (p *jsonPathParser) peek() byte {
+	if p.pos < len(p.expr) {
+		return p.expr[p.pos]
+	}
+	return 0
+}
+
+func (p *jsonPathParser) consume(s string) bool {
+	if strings.HasPrefix(p.expr[p.pos:], s) {
+		p.pos += len(s)
+		return true
+	}
+	return false
+}
+
+func (p *jsonPathParser) skipSpace() {
+	for p.peek() == ' ' {
+		p.pos++
+	}
+}
+
+// parsePath parses a path starting with the root character, either $ for an
+// absolute path or @ for a path relative to the node being filtered.
+func (p *jsonPathParser) parsePath(root byte) (*jsonPath, error) {
+	if p.peek() != root {
+		return nil, p.errorf("expected %q", root)
+	}
+	p.pos++
+
+	path := &jsonPath{}
+	for {
+		var (
+			seg jsonPathSegment
+			err error
+		)
+		segStart := p.pos
+
+		switch {
+		case p.consume(".."):
+			seg.recursive = true
+			if p.peek() == '[' {
+				seg, err = p.parseBracket()
+				seg.recursive = true
+			} else {
+				err = p.parseDotMember(&seg)
+			}
+		case p.consume("."):
+			err = p.parseDotMember(&seg)
+		case p.peek() == '[':
+			seg, err = p.parseBracket()
+		default:
+			return path, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+		seg.src = p.expr[segStart:p.pos]
+		path.segments = append(path.segments, seg)
+	}
+}
+
+// parseDotMember parses the member name or wildcard following a dot.
+func (p *jsonPathParser) parseDotMember(seg *jsonPathSegment) error {
+	if p.consume("*") {
+		seg.kind = jsonPathWildcard
+		return nil
+	}
+
+	start := p.pos
+	for p.pos < len(p.expr) && !strings.ContainsRune(".[]()=!<>&|, ", rune(p.expr[p.pos])) {
+		p.pos++
+	}
+	if p.pos == start {
+		return p.errorf("expected member name")
+	}
+
+	seg.kind = jsonPathMember
+	seg.name = p.expr[start:p.pos]
+	return nil
+}
+
+// parseBracket parses a bracketed segment.
+func (p *jsonPathParser) parseBracket() (jsonPathSegment, error) {
+	var seg jsonPathSegment
+	p.pos++ // [
+	p.skipSpace()
+
+	switch c := p.peek(); {
+	case c == '*':
+		p.pos++
+		seg.kind = jsonPathWildcard
+	case c == '\'' || c == '"':
+		name, err := p.parseString()
+		if err != nil {
+			return seg, err
+		}
+		seg.kind = jsonPathMember
+		seg.name = name
+	case c == '?':
+		p.pos++
+		p.skipSpace()
+		if !p.consume("(") {
+			return seg, p.errorf("expected ( after ?")
+		}
+		f, err := p.parseOr()
+		if err != nil {
+			return seg, err
+		}
+		p.skipSpace()
+		if !p.consume(")") {
+			return seg, p.errorf("expected ) to close filter")
+		}
+		seg.kind = jsonPathFilter
+		seg.filter = f
+	default:
+		if err := p.parseIndexOrSlice(&seg); err != nil {
+			return seg, err
+		}
+	}
+
+	p.skipSpace()
+	if !p.consume("]") {
+		return seg, p.errorf("expected ]")
+	}
+	return seg, nil
+}
+
+// parseIndexOrSlice parses an array index or slice.
+func (p *jsonPathParser) parseIndexOrSlice(seg *jsonPathSegment) error {
+	start, hasStart, err := p.parseInt()
+	if err != nil {
+		return err
+	}
+
+	p.skipSpace()
+	if !p.consume(":") {
+		if !hasStart {
+			return p.errorf("expected index, slice, name, wildcard, or filter")
+		}
+		seg.kind = jsonPathIndex
+		seg.index = start
+		return nil
+	}
+
+	seg.kind = jsonPathSlice
+	if hasStart {
+		seg.start = &start
+	}
+
+	p.skipSpace()
+	end, hasEnd, err := p.parseInt()
+	if err != nil {
+		return err
+	}
+	if hasEnd {
+		seg.end = &end
+	}
+	return nil
+}
+
+// parseInt parses an optional, possibly negative, integer.
+func (p *jsonPathParser) parseInt() (int, bool, error) {
+	start := p.pos
+	if p.peek() == '-' {
+		p.pos++
+	}
+	for p.peek() >= '0' && p.peek() <= '9' {
+		p.pos++
+	}
+	if p.pos == start {
+		return 0, false, nil
+	}
+
+	n, err := strconv.Atoi(p.expr[start:p.pos])
+	if err != nil {
+		return 0, false, p.errorf("invalid integer %q", p.expr[start:p.pos])
+	}
+	return n, true, nil
+}
+
+// parseString parses a single or double quoted string.
+func (p *jsonPathParser) parseString() (string, error) {
+	quote := p.peek()
+	p.pos++
+
+	var b strings.Builder
+	for p.pos < len(p.expr) {
+		c := p.expr[p.pos]
+		p.pos++
+		switch c {
+		case quote:
+			return b.String(), nil
+		case '\\':
+			if p.pos == len(p.expr) {
+				return "", p.errorf("unterminated string")
+			}
+			b.WriteByte(p.expr[p.pos])
+			p.pos++
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", p.errorf("unterminated string")
+}
+
+func (p *jsonPathParser) parseOr() (jsonFilter, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		p.skipSpace()
+		if !p.consume("||") {
+			return left, nil
+		}
+
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = jsonFilterOr{left: left, right: right}
+	}
+}
+
+func (p *jsonPathParser) parseAnd() (jsonFilter, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		p.skipSpace()
+		if !p.consume("&&") {
+			return left, nil
+		}
+
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = jsonFilterAnd{left: left, right: right}
+	}
+}
+
+func (p *jsonPathParser) parseUnary() (jsonFilter, error) {
+	p.skipSpace()
+
+	if p.peek() == '!' && !strings.HasPrefix(p.expr[p.pos:], "!=") {
+		p.pos++
+		f, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return jsonFilterNot{filter: f}, nil
+	}
+
+	if p.consume("(") {
+		f, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpace()
+		if !p.consume(")") {
+			return nil, p.errorf("expected )")
+		}
+		return f, nil
+	}
+
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipSpace()
+	for _, op := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if !p.consume(op) {
+			continue
+		}
+
+		p.skipSpace()
+		right, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		return jsonFilterCompare{op: op, left: left, right: right}, nil
+	}
+
+	if left.path == nil {
+		return nil, p.errorf("expected comparison after literal")
+	}
+	return jsonFilterExists{path: left.path}, nil
+}
+
+// parseOperand parses a relative path or a literal.
+func (p *jsonPathParser) parseOperand() (jsonOperand, error) {
+	switch c := p.peek(); {
+	case c == '@':
+		path, err := p.parsePath(c)
+		return jsonOperand{path: path}, err
+	case c == '\'' || c == '"':
+		s, err := p.parseString()
+		return jsonOperand{literal: s}, err
+	case p.consume("true"):
+		return jsonOperand{literal: true}, nil
+	case p.consume("false"):
+		return jsonOperand{literal: false}, nil
+	case p.consume("null"):
+		return jsonOperand{literal: nil}, nil
+	}
+
+	start := p.pos
+	for p.pos < len(p.expr) && strings.ContainsRune("+-.0123456789eE", rune(p.expr[p.pos])) {
+		p.pos++
+	}
+	if p.pos == start {
+		return jsonOperand{}, p.errorf("expected path or literal")
+	}
+
+	n := json.Number(p.expr[start:p.pos])
+	if _, ok := new(big.Rat).SetString(string(n)); !ok {
+		return jsonOperand{}, p.errorf("invalid number %q", n)
+	}
+	return jsonOperand{literal: n}, nil
+}
@@ -0,0 +1,559 @@
+package assert
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// MatchesJSONSchema asserts that a JSON document is valid according to a JSON
+// Schema. Both the document and the schema are provided in the same way as
+// JSONEqual. Every violation is reported, ordered by the JSON path of the
+// offending value, along with the location of the failing keyword within the
+// schema.
+//
+// The core validation keywords of draft 2020-12 are supported: type, enum,
+// const, properties, patternProperties, additionalProperties, required,
+// minProperties, maxProperties, prefixItems, items, minItems, maxItems,
+// uniqueItems, minLength, maxLength, pattern, minimum, maximum,
+// exclusiveMinimum, exclusiveMaximum, multipleOf, allOf, anyOf, oneOf, not,
+// if, then, else, and $ref pointing within the schema document. Patterns are
+// cached in the same way as RegexMatches.
+func MatchesJSONSchema(t testing.TB, doc, schema any) {
+	s, err := parseJSON(schema)
+	if err != nil {
+		t.Helper()
+		t.Fatalf("failed to parse JSON schema: %v", err)
+		return
+	}
+
+	d, err := parseJSON(doc)
+	if err != nil {
+		t.Helper()
+		t.Errorf("failed to parse JSON: %v", err)
+		return
+	}
+
+	v := &schemaValidator{root: s, refs: make(map[string]bool)}
+	violations := v.validate(d, s, "$", "#")
+
+	if len(violations) > 0 {
+		sort.SliceStable(violations, func(i, j int) bool {
+			return violations[i].instancePath < violations[j].instancePath
+		})
+
+		lines := make([]string, len(violations))
+		for i, violation := range violations {
+			lines[i] = violation.String()
+		}
+
+		t.Helper()
+		t.Errorf("JSON document does not match schema:\n%s", indent(strings.Join(lines, "\n"), "\t"))
+	}
+}
+
+// schemaViolation is a single failed validation.
+type schemaViolation struct {
+	instancePath string
+	schemaPath   string
+	msg          string
+}
+
+func (v schemaViolation) String() string {
+	return fmt.Sprintf("%s: %s (%s)", v.instancePath, v.msg, v.schemaPath)
+}
+
+// schemaValidator validates documents against a schema.
+type schemaValidator struct {
+	root any
+	// refs holds the references being followed for each instance path, and
+	// is used to detect references that loop without consuming the instance.
+	refs map[string]bool
+}
+
+// validate returns the violations of an instance against a schema.
+func (v *schemaValidator) validate(instance, schema any, ipath, spath string) []schemaViolation {
+	switch s := schema.(type) {
+	case bool:
+		if !s {
+			return []schemaViolation{{ipath, spath, "no value is allowed by a false schema"}}
+		}
+		return nil
+	case map[string]any:
+		var violations []schemaViolation
+		for _, k := range sortedKeys(s) {
+			violations = append(violations, v.keyword(instance, s, k, ipath, spath+"/"+escapePointer(k))...)
+		}
+		return violations
+	default:
+		return []schemaViolation{{ipath, spath, "invalid schema: must be an object or boolean"}}
+	}
+}
+
+// valid reports whether an instance is valid against a schema.
+func (v *schemaValidator) valid(instance, schema any, ipath, spath string) bool {
+	return len(v.validate(instance, schema, ipath, spath)) == 0
+}
+
+// keyword returns the violations of a single keyword of an object schema.
+func (v *schemaValidator) keyword(instance any, schema map[string]any, k, ipath, spath string) []schemaViolation {
+	value := schema[k]
+	fail := func(format string, args ...any) []schemaViolation {
+		return []schemaViolation{{ipath, spath, fmt.Sprintf(format, args...)}}
+	}
+	invalid := func(expected string) []schemaViolation {
+		return fail("invalid schema: %s must be %s", k, expected)
+	}
+
+	switch k {
+	case "$ref":
+		ref, ok := value.(string)
+		if !ok {
+			return invalid("a string")
+		}
+
+		target, ok := resolvePointer(v.root, ref)
+		if !ok {
+			return fail("invalid schema: cannot resolve reference %q", ref)
+		}
+
+		key := ipath + " " + ref
+		if v.refs[key] {
+			return fail("invalid schema: reference %q loops without consuming the instance", ref)
+		}
+		v.refs[key] = true
+		defer delete(v.refs, key)
+
+		return v.validate(instance, target, ipath, ref)
+
+	case "type":
+		var types []string
+		switch tv := value.(type) {
+		case string:
+			types = []string{tv}
+		case []any:
+			for _, t := range tv {
+				s, ok := t.(string)
+				if !ok {
+					return invalid("a string or array of strings")
+				}
+				types = append(types, s)
+			}
+		default:
+			return invalid("a string or array of strings")
+		}
+
+		for _, t := range types {
+			if hasJSONType(instance, t) {
+				return nil
+			}
+		}
+		return fail("expected type %s, got %s", strings.Join(types, " or "), jsonType(instance))
+
+	case "enum":
+		values, ok := value.([]any)
+		if !ok {
+			return invalid("an array")
+		}
+
+		for _, e := range values {
+			if jsonValuesEqual(e, instance) {
+				return nil
+			}
+		}
+		return fail("value %s is not one of %s", jsonString(instance), jsonString(values))
+
+	case "const":
+		if !jsonValuesEqual(value, instance) {
+			return fail("expected %s, got %s", jsonString(value), jsonString(instance))
+		}
+
+	case "properties", "patternProperties", "additionalProperties":
+		obj, ok := instance.(map[string]any)
+		if !ok {
+			return nil
+		}
+		return v.properties(obj, schema, k, ipath, spath)
+
+	case "required":
+		obj, ok := instance.(map[string]any)
+		if !ok {
+			return nil
+		}
+
+		names, ok := value.([]any)
+		if !ok {
+			return invalid("an array")
+		}
+
+		var violations []schemaViolation
+		for _, n := range names {
+			name, ok := n.(string)
+			if !ok {
+				return invalid("an array of strings")
+			}
+			if _, ok := obj[name]; !ok {
+				violations = append(violations, fail("missing required property %q", name)...)
+			}
+		}
+		return violations
+
+	case "minProperties", "maxProperties":
+		obj, ok := instance.(map[string]any)
+		if !ok {
+			return nil
+		}
+		return checkCount(k, len(obj), value, "properties", fail, invalid)
+
+	case "prefixItems", "items":
+		arr, ok := instance.([]any)
+		if !ok {
+			return nil
+		}
+		return v.items(arr, schema, k, ipath, spath)
+
+	case "minItems", "maxItems":
+		arr, ok := instance.([]any)
+		if !ok {
+			return nil
+		}
+		return checkCount(k, len(arr), value, "items", fail, invalid)
+
+	case "uniqueItems":
+		arr, ok := instance.([]any)
+		if !ok || value != true {
+			return nil
+		}
+
+		for i := range arr {
+			for j := 0; j < i; j++ {
+				if jsonValuesEqual(arr[i], arr[j]) {
+					return fail("items %d and %d are equal", j, i)
+				}
+			}
+		}
+
+	case "minLength", "maxLength":
+		s, ok := instance.(string)
+		if !ok {
+			return nil
+		}
+		return checkCount(k, utf8.RuneCountInString(s), value, "characters", fail, invalid)
+
+	case "pattern":
+		s, ok := instance.(string)
+		if !ok {
+			return nil
+		}
+
+		pattern, ok := value.(string)
+		if !ok {
+			return invalid("a string")
+		}
+
+		r, err := compileRegex(pattern)
+		if err != nil {
+			return fail("invalid schema: %v", err)
+		}
+		if !r.MatchString(s) {
+			return fail("string %s not matched by pattern /%s/", strconv.Quote(s), pattern)
+		}
+
+	case "minimum", "maximum", "exclusiveMinimum", "exclusiveMaximum", "multipleOf":
+		n, ok := instance.(json.Number)
+		if !ok {
+			return nil
+		}
+		return checkNumber(k, n, value, fail, invalid)
+
+	case "allOf", "anyOf", "oneOf":
+		schemas, ok := value.([]any)
+		if !ok || len(schemas) == 0 {
+			return invalid("a non-empty array")
+		}
+		return v.combine(instance, k, schemas, ipath, spath, fail)
+
+	case "not":
+		if v.valid(instance, value, ipath, spath) {
+			return fail("value must not match schema")
+		}
+
+	case "if":
+		thenSchema, hasThen := schema["then"]
+		elseSchema, hasElse := schema["else"]
+		parent := strings.TrimSuffix(spath, "/if")
+
+		if v.valid(instance, value, ipath, spath) {
+			if hasThen {
+				return v.validate(instance, thenSchema, ipath, parent+"/then")
+			}
+		} else if hasElse {
+			return v.validate(instance, elseSchema, ipath, parent+"/else")
+		}
+	}
+
+	// Unknown keywords, annotations such as title, and keywords that are
+	// only applied through others, such as then and $defs, are ignored.
+	return nil
+}
+
+// properties validates the members of an object against the properties,
+// patternProperties, and additionalProperties keywords. The additional
+// properties are those not matched by either of the other two keywords.
+func (v *schemaValidator) properties(obj, schema map[string]any, k, ipath, spath string) []schemaViolation {
+	props, _ := schema["properties"].(map[string]any)
+	patterns, _ := schema["patternProperties"].(map[string]any)
+
+	var violations []schemaViolation
+	for _, name := range sortedKeys(obj) {
+		member, memberPath := obj[name], jsonPathKey(ipath, name)
+
+		switch k {
+		case "properties":
+			if s, ok := props[name]; ok {
+				violations = append(violations, v.validate(member, s, memberPath, spath+"/"+escapePointer(name))...)
+			}
+
+		case "patternProperties":
+			for _, pattern := range sortedKeys(patterns) {
+				r, err := compileRegex(pattern)
+				if err != nil {
+					return []schemaViolation{{ipath, spath, fmt.Sprintf("invalid schema: %v", err)}}
+				}
+				if r.MatchString(name) {
+					violations = append(violations, v.validate(member, patterns[pattern], memberPath, spath+"/"+escapePointer(pattern))...)
+				}
+			}
+
+		case "additionalProperties":
+			if _, ok := props[name]; ok {
+				continue
+			}
+
+			matched := false
+			for pattern := range patterns {
+				if r, err := compileRegex(pattern); err == nil && r.MatchString(name) {
+					matched = true
+					break
+				}
+			}
+			if matched {
+				continue
+			}
+
+			if schema[k] == false {
+				violations = append(violations, schemaViolation{memberPath, spath, "additional property is not allowed"})
+				continue
+			}
+			violations = append(violations, v.validate(member, schema[k], memberPath, spath)...)
+		}
+	}
+	return violations
+}
+
+// items validates the elements of an array against the prefixItems and items
+// keywords. The items schema applies to every element after the prefix.
+func (v *schemaValidator) items(arr []any, schema map[string]any, k, ipath, spath string) []schemaViolation {
+	prefix, _ := schema["prefixItems"].([]any)
+
+	var violations []schemaViolation
+	switch k {
+	case "prefixItems":
+		for i, s := range prefix {
+			if i >= len(arr) {
+				break
+			}
+			violations = append(violations, v.validate(arr[i], s, fmt.Sprintf("%s[%d]", ipath, i), fmt.Sprintf("%s/%d", spath, i))...)
+		}
+	case "items":
+		for i := len(prefix); i < len(arr); i++ {
+			ipath := fmt.Sprintf("%s[%d]", ipath, i)
+			if schema[k] == false {
+				violations = append(violations, schemaViolation{ipath, spath, "additional item is not allowed"})
+				continue
+			}
+			violations = append(violations, v.validate(arr[i], schema[k], ipath, spath)...)
+		}
+	}
+	return violations
+}
+
+// combine validates an instance against the subschemas of allOf, anyOf, or
+// oneOf.
+func (v *schemaValidator) combine(instance any, k string, schemas []any, ipath, spath string, fail func(string, ...any) []schemaViolation) []schemaViolation {
+	var (
+		violations []schemaViolation
+		matched    []string
+	)
+
+	for i, s := range schemas {
+		sv := v.validate(instance, s, ipath, fmt.Sprintf("%s/%d", spath, i))
+		if len(sv) == 0 {
+			matched = append(matched, strconv.Itoa(i))
+		}
+		violations = append(violations, sv...)
+	}
+
+	switch k {
+	case "allOf":
+		return violations
+	case "anyOf":
+		if len(matched) == 0 {
+			return append(fail("value does not match any schema"), violations...)
+		}
+	case "oneOf":
+		if len(matched) == 0 {
+			return append(fail("value does not match exactly one schema, matched none"), violations...)
+		}
+		if len(matched) > 1 {
+			return fail("value does not match exactly one schema, matched %s", strings.Join(matched, ", "))
+		}
+	}
+	return nil
+}
+
+// checkCount validates the min* and max* keywords that bound a count.
+func checkCount(k string, count int, value any, unit string, fail func(string, ...any) []schemaViolation, invalid func(string) []schemaViolation) []schemaViolation {
+	n, ok := value.(json.Number)
+	if !ok {
+		return invalid("a non-negative integer")
+	}
+
+	limit, err := strconv.Atoi(string(n))
+	if err != nil || limit < 0 {
+		return invalid("a non-negative integer")
+	}
+
+	if strings.HasPrefix(k, "min") && count < limit {
+		return fail("expected at least %d %s, got %d", limit, unit, count)
+	}
+	if strings.HasPrefix(k, "max") && count > limit {
+		return fail("expected at most %d %s, got %d", limit, unit, count)
+	}
+	return nil
+}
+
+// checkNumber validates the keywords that constrain numbers.
+func checkNumber(k string, n json.Number, value any, fail func(string, ...any) []schemaViolation, invalid func(string) []schemaViolation) []schemaViolation {
+	limit, ok := value.(json.Number)
+	if !ok {
+		return invalid("a number")
+	}
+
+	rn, ok1 := new(big.Rat).SetString(string(n))
+	rl, ok2 := new(big.Rat).SetString(string(limit))
+	if !ok1 || !ok2 {
+		return invalid("a number")
+	}
+
+	cmp := rn.Cmp(rl)
+	switch k {
+	case "minimum":
+		if cmp < 0 {
+			return fail("value %s is less than minimum %s", n, limit)
+		}
+	case "maximum":
+		if cmp > 0 {
+			return fail("value %s is greater than maximum %s", n, limit)
+		}
+	case "exclusiveMinimum":
+		if cmp <= 0 {
+			return fail("value %s is not greater than exclusive minimum %s", n, limit)
+		}
+	case "exclusiveMaximum":
+		if cmp >= 0 {
+			return fail("value %s is not less than exclusive maximum %s", n, limit)
+		}
+	case "multipleOf":
+		if rl.Sign() <= 0 {
+			return invalid("a number greater than 0")
+		}
+		if !new(big.Rat).Quo(rn, rl).IsInt() {
+			return fail("value %s is not a multiple of %s", n, limit)
+		}
+	}
+	return nil
+}
+
+// hasJSONType reports whether a value is of a JSON Schema type.
+func hasJSONType(v any, t string) bool {
+	if t == "integer" {
+		n, ok := v.(json.Number)
+		if !ok {
+			return false
+		}
+		r, ok := new(big.Rat).SetString(string(n))
+		return ok && r.IsInt()
+	}
+	return jsonType(v) == t
+}
+
+// jsonType returns the JSON Schema type of a value.
+func jsonType(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case json.Number:
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+// resolvePointer resolves a reference holding a JSON Pointer fragment, such as
+// #/$defs/user, within a document.
+func resolvePointer(doc any, ref string) (any, bool) {
+	if !strings.HasPrefix(ref, "#") {
+		return nil, false
+	}
+
+	fragment, err := url.PathUnescape(ref[1:])
+	if err != nil {
+		return nil, false
+	}
+	if fragment == "" {
+		return doc, true
+	}
+	if !strings.HasPrefix(fragment, "/") {
+		return nil, false
+	}
+
+	node := doc
+	for _, token := range strings.Split(fragment[1:], "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+
+		switch n := node.(type) {
+		case map[string]any:
+			var ok bool
+			if node, ok = n[token]; !ok {
+				return nil, false
+			}
+		case []any:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(n) {
+				return nil, false
+			}
+			node = n[i]
+		default:
+			return nil, false
+		}
+	}
+	return node, true
+}
+
+// escapePointer escapes a token for use in a JSON Pointer.
+func escapePointer(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
@@ -0,0 +1,162 @@
+package assert
+
+import (
+	"testing"
+)
+
+const userSchema = `{
+	"$defs": {
+		"tag": {"type": "string", "pattern": "^[a-z]+$"}
+	},
+	"type": "object",
+	"required": ["id", "name"],
+	"properties": {
+		"id": {"type": "integer", "minimum": 1},
+		"name": {"type": "string", "minLength": 1, "maxLength": 8},
+		"email": {"type": ["string", "null"]},
+		"role": {"enum": ["admin", "user"]},
+		"tags": {"type": "array", "items": {"$ref": "#/$defs/tag"}, "uniqueItems": true},
+		"score": {"type": "number", "exclusiveMaximum": 100, "multipleOf": 0.5}
+	},
+	"additionalProperties": false
+}`
+
+func TestMatchesJSONSchema(t *testing.T) {
+	tests := []struct {
+		name               string
+		doc                any
+		schema             any
+		expectedMessage    string
+		expectedErrorCalls int
+		expectedFatalCalls int
+	}{
+		{
+			name:   "Valid document",
+			doc:    `{"id": 1, "name": "alice", "email": null, "role": "admin", "tags": ["a", "b"], "score": 99.5}`,
+			schema: userSchema,
+		},
+		{
+			name:   "Marshaled values",
+			doc:    map[string]any{"id": 2, "name": "bob"},
+			schema: map[string]any{"type": "object", "required": []string{"id"}},
+		},
+		{
+			name:   "Boolean schema",
+			doc:    `[1, "a", null]`,
+			schema: `true`,
+		},
+		{
+			name:   "Integral number",
+			doc:    `1.0`,
+			schema: `{"type": "integer"}`,
+		},
+		{
+			name:   "Prefix items",
+			doc:    `["x", 1, 2]`,
+			schema: `{"prefixItems": [{"type": "string"}], "items": {"type": "integer"}, "minItems": 3}`,
+		},
+		{
+			name:   "Conditional",
+			doc:    `{"kind": "a", "a": 1}`,
+			schema: `{"if": {"properties": {"kind": {"const": "a"}}}, "then": {"required": ["a"]}, "else": {"required": ["b"]}}`,
+		},
+		{
+			name:   "Recursive reference",
+			doc:    `{"value": 1, "next": {"value": 2, "next": {"value": 3}}}`,
+			schema: `{"type": "object", "required": ["value"], "properties": {"next": {"$ref": "#"}}}`,
+		},
+		{
+			name:   "Every violation",
+			doc:    `{"name": "a very long name", "email": 3, "role": "root", "tags": ["ok", "Bad", "ok"], "score": 100, "extra": true}`,
+			schema: userSchema,
+			expectedMessage: "JSON document does not match schema:\n" +
+				"\t$: missing required property \"id\" (#/required)\n" +
+				"\t$.email: expected type string or null, got number (#/properties/email/type)\n" +
+				"\t$.extra: additional property is not allowed (#/additionalProperties)\n" +
+				"\t$.name: expected at most 8 characters, got 16 (#/properties/name/maxLength)\n" +
+				"\t$.role: value \"root\" is not one of [\"admin\",\"user\"] (#/properties/role/enum)\n" +
+				"\t$.score: value 100 is not less than exclusive maximum 100 (#/properties/score/exclusiveMaximum)\n" +
+				"\t$.tags: items 0 and 2 are equal (#/properties/tags/uniqueItems)\n" +
+				"\t$.tags[1]: string \"Bad\" not matched by pattern /^[a-z]+$/ (#/$defs/tag/pattern)",
+			expectedErrorCalls: 1,
+		},
+		{
+			name:               "False schema",
+			doc:                `{"a": 1}`,
+			schema:             `{"properties": {"a": false}}`,
+			expectedMessage:    "JSON document does not match schema:\n\t$.a: no value is allowed by a false schema (#/properties/a)",
+			expectedErrorCalls: 1,
+		},
+		{
+			name:               "Any of",
+			doc:                `"x"`,
+			schema:             `{"anyOf": [{"type": "integer"}, {"type": "boolean"}]}`,
+			expectedMessage:    "JSON document does not match schema:\n\t$: value does not match any schema (#/anyOf)\n\t$: expected type integer, got string (#/anyOf/0/type)\n\t$: expected type boolean, got string (#/anyOf/1/type)",
+			expectedErrorCalls: 1,
+		},
+		{
+			name:               "One of matching several",
+			doc:                `4`,
+			schema:             `{"oneOf": [{"type": "integer"}, {"minimum": 2}, {"maximum": 2}]}`,
+			expectedMessage:    "JSON document does not match schema:\n\t$: value does not match exactly one schema, matched 0, 1 (#/oneOf)",
+			expectedErrorCalls: 1,
+		},
+		{
+			name:               "Not",
+			doc:                `null`,
+			schema:             `{"not": {"type": "null"}}`,
+			expectedMessage:    "JSON document does not match schema:\n\t$: value must not match schema (#/not)",
+			expectedErrorCalls: 1,
+		},
+		{
+			name:               "Unresolvable reference",
+			doc:                `1`,
+			schema:             `{"$ref": "#/$defs/missing"}`,
+			expectedMessage:    "JSON document does not match schema:\n\t$: invalid schema: cannot resolve reference \"#/$defs/missing\" (#/$ref)",
+			expectedErrorCalls: 1,
+		},
+		{
+			name:               "Looping reference",
+			doc:                `1`,
+			schema:             `{"$defs": {"a": {"$ref": "#/$defs/b"}, "b": {"$ref": "#/$defs/a"}}, "$ref": "#/$defs/a"}`,
+			expectedErrorCalls: 1,
+		},
+		{
+			name:               "Invalid document",
+			doc:                `{`,
+			schema:             `true`,
+			expectedErrorCalls: 1,
+		},
+		{
+			name:               "Invalid schema",
+			doc:                `{}`,
+			schema:             `{"type": }`,
+			expectedFatalCalls: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+
+			MatchesJSONSchema(mockT, tt.doc, tt.schema)
+			n := len(mockT.ErrorfCalls)
+			m := len(mockT.FatalfCalls)
+
+			if n != tt.expectedErrorCalls {
+				t.Fatalf("expected %d calls to Errorf(), got %d", tt.expectedErrorCalls, n)
+			}
+
+			if m != tt.expectedFatalCalls {
+				t.Errorf("expected %d calls to Fatalf(), got %d", tt.expectedFatalCalls, m)
+			}
+
+			if mockT.HelperCalls != n+m {
+				t.Errorf("expected %d calls to Helper(), got %d", n+m, mockT.HelperCalls)
+			}
+
+			if tt.expectedMessage != "" && mockT.ErrorfCalls[0].message() != tt.expectedMessage {
+				t.Errorf("expected message:\n%s\ngot:\n%s", tt.expectedMessage, mockT.ErrorfCalls[0].message())
+			}
+		})
+	}
+}
@@ -0,0 +1,274 @@
+package assert
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// LeakOption configures how NoGoroutineLeaks and RunNoGoroutineLeaks detect
+// leaked goroutines.
+type LeakOption func(*leakConfig)
+
+// leakConfig holds the settings of a goroutine leak check.
+type leakConfig struct {
+	timeout     time.Duration
+	topFuncs    []string
+	anyFuncs    []string
+	ignoredByID map[int]bool
+}
+
+// defaultLeakTimeout is the time waited for goroutines to exit when no
+// LeakTimeout option is provided.
+const defaultLeakTimeout = time.Second
+
+// LeakTimeout sets the time waited for goroutines to exit before they are
+// reported as leaked.
+func LeakTimeout(d time.Duration) LeakOption {
+	return func(c *leakConfig) {
+		c.timeout = d
+	}
+}
+
+// IgnoreTopFunction ignores goroutines whose stack is topped by a function,
+// given by its fully qualified name, such as "net/http.(*persistConn).readLoop".
+func IgnoreTopFunction(fn string) LeakOption {
+	return func(c *leakConfig) {
+		c.topFuncs = append(c.topFuncs, fn)
+	}
+}
+
+// IgnoreAnyFunction ignores goroutines with a function anywhere in their
+// stack, given by its fully qualified name.
+func IgnoreAnyFunction(fn string) LeakOption {
+	return func(c *leakConfig) {
+		c.anyFuncs = append(c.anyFuncs, fn)
+	}
+}
+
+func newLeakConfig(opts []LeakOption) *leakConfig {
+	c := &leakConfig{timeout: defaultLeakTimeout}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// knownFuncs are functions whose goroutines are started by the runtime and
+// the testing package, and are never leaks.
+var knownFuncs = []string{
+	"testing.tRunner",
+	"testing.(*T).Run",
+	"testing.(*M).Run",
+	"testing.RunTests",
+	"testing.runTests",
+	"testing.(*M).startAlarm",
+	"os/signal.signal_recv",
+	"os/signal.loop",
+	"runtime.ensureSigM",
+	"runtime.ReadTrace",
+}
+
+// NoGoroutineLeaks asserts that every goroutine started during a test has
+// exited by the time the test finishes. The running goroutines are recorded
+// when it is called and compared against those still running when the test's
+// cleanup functions run, after waiting up to a timeout for new goroutines to
+// exit. Goroutines of the runtime and testing package are ignored.
+//
+// Leaks are reported when the test finishes, so the report starts with the
+// file and line of the call to NoGoroutineLeaks.
+//
+// NoGoroutineLeaks should be called at the start of a test, before other
+// cleanup functions are registered, so that it runs after them. It cannot be
+// used with parallel tests, whose goroutines cannot be told apart.
+func NoGoroutineLeaks(t testing.TB, opts ...LeakOption) {
+	c := newLeakConfig(opts)
+	c.ignoredByID = make(map[int]bool)
+	for _, g := range goroutineStacks() {
+		c.ignoredByID[g.id] = true
+	}
+
+	// Failures in cleanup functions are reported at the cleanup function, so
+	// the report starts with the location of the call instead.
+	var caller string
+	if _, file, line, ok := runtime.Caller(1); ok {
+		caller = fmt.Sprintf("%s:%d: ", filepath.Base(file), line)
+	}
+
+	t.Cleanup(func() {
+		if leaks := c.wait(); len(leaks) > 0 {
+			t.Helper()
+			t.Errorf("%s%s", caller, formatLeaks(leaks))
+		}
+	})
+}
+
+// RunNoGoroutineLeaks runs the tests of a package with m.Run and then checks
+// that no goroutines other than the main goroutine are left running, in the
+// same way as NoGoroutineLeaks. Leaked goroutines are written to standard
+// error. It should be called from TestMain, with its result passed to os.Exit.
+// Leaks are only checked for when every test passed.
+func RunNoGoroutineLeaks(m *testing.M, opts ...LeakOption) int {
+	code := m.Run()
+	if code != 0 {
+		return code
+	}
+
+	if leaks := newLeakConfig(opts).wait(); len(leaks) > 0 {
+		fmt.Fprintln(os.Stderr, formatLeaks(leaks))
+		return 1
+	}
+
+	return code
+}
+
+// wait polls the running goroutines until none are leaked or the timeout
+// elapses, returning the leaked goroutines of the last check.
+func (c *leakConfig) wait() []goroutine {
+	deadline := time.Now().Add(c.timeout)
+	interval := time.Millisecond
+
+	for {
+		leaks := c.leaks()
+		if len(leaks) == 0 {
+			return nil
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return leaks
+		}
+
+		if interval > remaining {
+			interval = remaining
+		}
+		time.Sleep(interval)
+		if interval < 100*time.Millisecond {
+			interval *= 2
+		}
+	}
+}
+
+// leaks returns the running goroutines that are not ignored, other than the
+// calling goroutine.
+func (c *leakConfig) leaks() []goroutine {
+	var leaks []goroutine
+	for i, g := range goroutineStacks() {
+		if i == 0 || c.ignored(g) {
+			continue
+		}
+		leaks = append(leaks, g)
+	}
+	return leaks
+}
+
+func (c *leakConfig) ignored(g goroutine) bool {
+	if c.ignoredByID[g.id] {
+		return true
+	}
+
+	if len(g.funcs) > 0 {
+		for _, fn := range c.topFuncs {
+			if g.funcs[0] == fn {
+				return true
+			}
+		}
+	}
+
+	for _, f := range g.funcs {
+		for _, fn := range c.anyFuncs {
+			if f == fn {
+				return true
+			}
+		}
+		for _, fn := range knownFuncs {
+			if f == fn {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// goroutine is a goroutine parsed from the output of runtime.Stack.
+type goroutine struct {
+	id int
+	// funcs are the functions of the goroutine's stack, from the top.
+	funcs []string
+	stack string
+}
+
+// goroutineStacks returns the running goroutines, starting with the calling
+// goroutine.
+func goroutineStacks() []goroutine {
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+
+	var gs []goroutine
+	for _, block := range strings.Split(string(buf), "\n\n") {
+		if g, ok := parseGoroutine(block); ok {
+			gs = append(gs, g)
+		}
+	}
+	return gs
+}
+
+// parseGoroutine parses the stack of a single goroutine, which starts with a
+// header such as "goroutine 7 [chan receive]:".
+func parseGoroutine(block string) (goroutine, bool) {
+	lines := strings.Split(strings.TrimSpace(block), "\n")
+
+	header := strings.TrimPrefix(lines[0], "goroutine ")
+	idText, _, ok := strings.Cut(header, " ")
+	if !ok {
+		return goroutine{}, false
+	}
+
+	id, err := strconv.Atoi(idText)
+	if err != nil {
+		return goroutine{}, false
+	}
+
+	g := goroutine{
+		id:    id,
+		stack: strings.TrimSpace(block),
+	}
+
+	for _, l := range lines[1:] {
+		if strings.HasPrefix(l, "\t") || strings.HasPrefix(l, "created by ") {
+			continue
+		}
+		if i := strings.LastIndexByte(l, '('); i > 0 && strings.HasSuffix(l, ")") {
+			l = l[:i]
+		}
+		g.funcs = append(g.funcs, l)
+	}
+
+	return g, true
+}
+
+// formatLeaks describes leaked goroutines with their stacks.
+func formatLeaks(leaks []goroutine) string {
+	stacks := make([]string, len(leaks))
+	for i, g := range leaks {
+		stacks[i] = indent(g.stack, "\t")
+	}
+	noun := "goroutines"
+	if len(leaks) == 1 {
+		noun = "goroutine"
+	}
+	return fmt.Sprintf("found %d leaked %s:\n%s", len(leaks), noun, strings.Join(stacks, "\n\n"))
+}
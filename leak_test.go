@@ -0,0 +1,149 @@
+package assert
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func leakyWorker(ch chan struct{}) {
+	<-ch
+}
+
+func leakyCaller(ch chan struct{}) {
+	leakyWorker(ch)
+}
+
+func TestNoGoroutineLeaks(t *testing.T) {
+	tests := []struct {
+		name          string
+		start         func(ch chan struct{})
+		opts          []LeakOption
+		expectedCalls int
+	}{
+		{
+			name:  "Goroutine exits before timeout",
+			start: func(ch chan struct{}) { go time.Sleep(10 * time.Millisecond) },
+		},
+		{
+			name:          "Leaked goroutine",
+			start:         func(ch chan struct{}) { go leakyWorker(ch) },
+			expectedCalls: 1,
+		},
+		{
+			name:  "Ignored top function",
+			start: func(ch chan struct{}) { go leakyWorker(ch) },
+			opts:  []LeakOption{IgnoreTopFunction("github.com/mattmeyers/assert.leakyWorker")},
+		},
+		{
+			name:          "Top function not ignored for caller",
+			start:         func(ch chan struct{}) { go leakyCaller(ch) },
+			opts:          []LeakOption{IgnoreTopFunction("github.com/mattmeyers/assert.leakyCaller")},
+			expectedCalls: 1,
+		},
+		{
+			name:  "Ignored function anywhere in stack",
+			start: func(ch chan struct{}) { go leakyCaller(ch) },
+			opts:  []LeakOption{IgnoreAnyFunction("github.com/mattmeyers/assert.leakyCaller")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+			ch := make(chan struct{})
+			defer close(ch)
+
+			_, _, line, _ := runtime.Caller(0)
+			NoGoroutineLeaks(mockT, append([]LeakOption{LeakTimeout(50 * time.Millisecond)}, tt.opts...)...)
+			tt.start(ch)
+			mockT.RunCleanups()
+
+			n := len(mockT.ErrorfCalls)
+			if n != tt.expectedCalls {
+				t.Fatalf("expected %d calls to Errorf(), got %d", tt.expectedCalls, n)
+			}
+
+			if n != mockT.HelperCalls {
+				t.Errorf("expected %d calls to Helper(), got %d", n, mockT.HelperCalls)
+			}
+
+			if n == 0 {
+				return
+			}
+
+			msg := mockT.ErrorfCalls[0].message()
+			prefix := fmt.Sprintf("leak_test.go:%d: found 1 leaked goroutine:\n\tgoroutine ", line+1)
+			if !strings.HasPrefix(msg, prefix) || !strings.Contains(msg, "\n\tgithub.com/mattmeyers/assert.leakyWorker(") {
+				t.Errorf("expected message to list the leaked goroutine, got:\n%s", msg)
+			}
+		})
+	}
+}
+
+func TestFormatLeaks(t *testing.T) {
+	tests := []struct {
+		name     string
+		leaks    []goroutine
+		expected string
+	}{
+		{
+			name:     "Single goroutine",
+			leaks:    []goroutine{{stack: "goroutine 7 [select]:\nmain.worker()"}},
+			expected: "found 1 leaked goroutine:\n\tgoroutine 7 [select]:\n\tmain.worker()",
+		},
+		{
+			name:     "Several goroutines",
+			leaks:    []goroutine{{stack: "goroutine 7 [select]:"}, {stack: "goroutine 8 [sleep]:"}},
+			expected: "found 2 leaked goroutines:\n\tgoroutine 7 [select]:\n\n\tgoroutine 8 [sleep]:",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatLeaks(tt.leaks); got != tt.expected {
+				t.Errorf("expected message:\n%s\ngot:\n%s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestParseGoroutine(t *testing.T) {
+	block := `goroutine 21 [chan receive, 2 minutes]:
+net/http.(*persistConn).readLoop(0xc0001b2000)
+	/usr/local/go/src/net/http/transport.go:2205 +0x153
+example.com/pkg.serve[...](0x1, {0x2, 0x3})
+	/src/pkg/serve.go:10 +0x25
+created by net/http.(*Transport).dialConn in goroutine 7
+	/usr/local/go/src/net/http/transport.go:1765 +0x16ea`
+
+	g, ok := parseGoroutine(block)
+	if !ok {
+		t.Fatal("expected goroutine to be parsed")
+	}
+
+	if g.id != 21 {
+		t.Errorf("expected id 21, got %d", g.id)
+	}
+
+	expected := []string{"net/http.(*persistConn).readLoop", "example.com/pkg.serve[...]"}
+	if !reflect.DeepEqual(g.funcs, expected) {
+		t.Errorf("expected functions %q, got %q", expected, g.funcs)
+	}
+
+	if _, ok := parseGoroutine("not a goroutine"); ok {
+		t.Errorf("expected invalid block not to be parsed")
+	}
+}
+
+func TestGoroutineStacks(t *testing.T) {
+	gs := goroutineStacks()
+	if len(gs) == 0 || len(gs[0].funcs) == 0 {
+		t.Fatal("expected the calling goroutine")
+	}
+
+	if top := gs[0].funcs[0]; top != "github.com/mattmeyers/assert.goroutineStacks" {
+		t.Errorf("expected calling goroutine first, got top function %s", top)
+	}
+}
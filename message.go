@@ -0,0 +1,95 @@
+package assert
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// Option describes a piece of context that is appended to the failure message
+// of an assertion. Options are attached to assertions by wrapping a testing.TB
+// with With.
+type Option func() string
+
+// Msg builds an Option that appends a formatted message to failures.
+func Msg(format string, args ...any) Option {
+	return func() string {
+		return fmt.Sprintf(format, args...)
+	}
+}
+
+// KV builds an Option that annotates failures with key-value pairs. Each pair
+// is written on its own line. A trailing key without a value is reported as
+// missing its value.
+func KV(keysAndValues ...any) Option {
+	return func() string {
+		lines := make([]string, 0, (len(keysAndValues)+1)/2)
+		for i := 0; i < len(keysAndValues); i += 2 {
+			if i+1 == len(keysAndValues) {
+				lines = append(lines, fmt.Sprintf("%v: <missing value>", keysAndValues[i]))
+				break
+			}
+			lines = append(lines, fmt.Sprintf("%v: %v", keysAndValues[i], keysAndValues[i+1]))
+		}
+		return strings.Join(lines, "\n")
+	}
+}
+
+// Lazy builds an Option whose message is only computed when an assertion
+// fails. This is useful when describing the failure is expensive.
+func Lazy(fn func() string) Option {
+	return Option(fn)
+}
+
+// MessageTB is a wrapper around a testing.TB that appends the messages of its
+// options to every failure. Both fatal and non-fatal failures are annotated, so
+// a MessageTB can wrap or be wrapped by a FatalTB.
+type MessageTB struct {
+	testing.TB
+	opts []Option
+}
+
+// With builds a MessageTB for attaching context to the failure messages of
+// any assertion.
+func With(t testing.TB, opts ...Option) *MessageTB {
+	return &MessageTB{TB: t, opts: opts}
+}
+
+func (t *MessageTB) Error(args ...any) {
+	t.TB.Helper()
+	t.TB.Error(t.annotate(sprintln(args...)))
+}
+
+func (t *MessageTB) Errorf(format string, args ...any) {
+	t.TB.Helper()
+	t.TB.Errorf("%s", t.annotate(fmt.Sprintf(format, args...)))
+}
+
+func (t *MessageTB) Fatal(args ...any) {
+	t.TB.Helper()
+	t.TB.Fatal(t.annotate(sprintln(args...)))
+}
+
+func (t *MessageTB) Fatalf(format string, args ...any) {
+	t.TB.Helper()
+	t.TB.Fatalf("%s", t.annotate(fmt.Sprintf(format, args...)))
+}
+
+// annotate appends the message of every option to msg, one per line.
+func (t *MessageTB) annotate(msg string) string {
+	var b strings.Builder
+	b.WriteString(msg)
+	for _, opt := range t.opts {
+		if s := opt(); s != "" {
+			b.WriteByte('\n')
+			b.WriteString(s)
+		}
+	}
+	return b.String()
+}
+
+// sprintln formats its arguments the same way as testing.TB.Error, with spaces
+// between all operands, but without the trailing newline.
+func sprintln(args ...any) string {
+	return strings.TrimSuffix(fmt.Sprintln(args...), "\n")
+}
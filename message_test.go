@@ -0,0 +1,114 @@
+package assert
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWith(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []Option
+		expected string
+	}{
+		{
+			name:     "No options",
+			opts:     nil,
+			expected: `expected "2", got "1"`,
+		},
+		{
+			name:     "Formatted message",
+			opts:     []Option{Msg("row %d", 3)},
+			expected: "expected \"2\", got \"1\"\nrow 3",
+		},
+		{
+			name:     "Key-value pairs",
+			opts:     []Option{KV("user", "alice", "attempt", 2)},
+			expected: "expected \"2\", got \"1\"\nuser: alice\nattempt: 2",
+		},
+		{
+			name:     "Missing value",
+			opts:     []Option{KV("user")},
+			expected: "expected \"2\", got \"1\"\nuser: <missing value>",
+		},
+		{
+			name:     "Multiple options",
+			opts:     []Option{Msg("row %d", 3), Lazy(func() string { return "computed" })},
+			expected: "expected \"2\", got \"1\"\nrow 3\ncomputed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+
+			Equal(With(mockT, tt.opts...), 1, 2)
+
+			if len(mockT.ErrorfCalls) != 1 {
+				t.Fatalf("expected 1 call to Errorf(), got %d", len(mockT.ErrorfCalls))
+			}
+
+			if msg := mockT.ErrorfCalls[0].message(); msg != tt.expected {
+				t.Errorf("expected message %q, got %q", tt.expected, msg)
+			}
+		})
+	}
+}
+
+func TestWithLazyNotEvaluatedOnSuccess(t *testing.T) {
+	mockT := newMockTB()
+	called := false
+
+	Equal(With(mockT, Lazy(func() string {
+		called = true
+		return ""
+	})), 1, 1)
+
+	if called {
+		t.Error("expected lazy option to not be evaluated")
+	}
+}
+
+func TestWithError(t *testing.T) {
+	mockT := newMockTB()
+
+	Error(With(mockT, Msg("context")), nil)
+
+	if len(mockT.ErrorCalls) != 1 {
+		t.Fatalf("expected 1 call to Error(), got %d", len(mockT.ErrorCalls))
+	}
+
+	if msg := mockT.ErrorCalls[0].args[0]; msg != "expected error, got nil\ncontext" {
+		t.Errorf("unexpected message %q", msg)
+	}
+}
+
+func TestWithFatal(t *testing.T) {
+	tests := []struct {
+		name string
+		wrap func(t testing.TB) testing.TB
+	}{
+		{
+			name: "With wrapping Fatal",
+			wrap: func(t testing.TB) testing.TB { return With(Fatal(t), Msg("context")) },
+		},
+		{
+			name: "Fatal wrapping With",
+			wrap: func(t testing.TB) testing.TB { return Fatal(With(t, Msg("context"))) },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+
+			NoError(tt.wrap(mockT), errors.New("test error"))
+
+			if len(mockT.FatalfCalls) != 1 {
+				t.Fatalf("expected 1 call to Fatalf(), got %d", len(mockT.FatalfCalls))
+			}
+
+			if msg := mockT.FatalfCalls[0].message(); msg != "expected no error, got \"test error\"\ncontext" {
+				t.Errorf("unexpected message %q", msg)
+			}
+		})
+	}
+}
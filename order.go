@@ -0,0 +1,144 @@
+package assert
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// Bounds selects which ends of a range are excluded by InRange. The zero value
+// includes both bounds.
+type Bounds uint8
+
+const (
+	// Inclusive includes both bounds, as in the interval [low, high].
+	Inclusive Bounds = 0
+	// ExcludeLow excludes the lower bound, as in the interval (low, high].
+	ExcludeLow Bounds = 1
+	// ExcludeHigh excludes the upper bound, as in the interval [low, high).
+	ExcludeHigh Bounds = 2
+	// Exclusive excludes both bounds, as in the interval (low, high).
+	Exclusive = ExcludeLow | ExcludeHigh
+)
+
+// interval formats a range in interval notation.
+func (b Bounds) interval(low, high string) string {
+	left, right := "[", "]"
+	if b&ExcludeLow != 0 {
+		left = "("
+	}
+	if b&ExcludeHigh != 0 {
+		right = ")"
+	}
+	return left + low + ", " + high + right
+}
+
+// Between asserts that a value is greater than or equal to low and less than
+// or equal to high.
+func Between[T Ordered](t testing.TB, got, low, high T) {
+	if !(low <= high) {
+		t.Helper()
+		t.Fatalf("invalid range %s", Inclusive.interval(formatValue(low), formatValue(high)))
+		return
+	}
+
+	if reason := rangeReason(got, low, high, Inclusive); reason != "" {
+		t.Helper()
+		t.Errorf("expected %s to be in range %s, but %s", formatValue(got), Inclusive.interval(formatValue(low), formatValue(high)), reason)
+	}
+}
+
+// InRange asserts that a value is within the range from low to high, with
+// each bound included unless it is excluded by bounds.
+func InRange[T Ordered](t testing.TB, got, low, high T, bounds Bounds) {
+	if !(low <= high) {
+		t.Helper()
+		t.Fatalf("invalid range %s", bounds.interval(formatValue(low), formatValue(high)))
+		return
+	}
+
+	if reason := rangeReason(got, low, high, bounds); reason != "" {
+		t.Helper()
+		t.Errorf("expected %s to be in range %s, but %s", formatValue(got), bounds.interval(formatValue(low), formatValue(high)), reason)
+	}
+}
+
+// rangeReason explains why got is not within a range, returning an empty
+// string if it is.
+func rangeReason[T Ordered](got, low, high T, bounds Bounds) string {
+	switch {
+	case got != got:
+		return "it is NaN"
+	case got < low:
+		return "it is less than the lower bound"
+	case got > high:
+		return "it is greater than the upper bound"
+	case got == low && bounds&ExcludeLow != 0:
+		return "it is equal to the excluded lower bound"
+	case got == high && bounds&ExcludeHigh != 0:
+		return "it is equal to the excluded upper bound"
+	}
+	return ""
+}
+
+// OneOf asserts that a value is equal to one of the allowed values.
+func OneOf[T comparable](t testing.TB, got T, allowed ...T) {
+	for _, v := range allowed {
+		if got == v {
+			return
+		}
+	}
+
+	values := make([]string, len(allowed))
+	for i, v := range allowed {
+		values[i] = formatValue(v)
+	}
+
+	t.Helper()
+	t.Errorf("expected one of [%s], got %s", strings.Join(values, ", "), formatValue(got))
+}
+
+// Sorted asserts that a slice is sorted in ascending order. Equal adjacent
+// elements are allowed.
+func Sorted[T Ordered](t testing.TB, s []T) {
+	for i := 1; i < len(s); i++ {
+		if !(s[i] >= s[i-1]) {
+			t.Helper()
+			t.Errorf("slice is not sorted at index %d: %s", i, orderReason(s[i], s[i-1], "less than"))
+			return
+		}
+	}
+}
+
+// StrictlySorted asserts that a slice is sorted in ascending order with no
+// equal adjacent elements.
+func StrictlySorted[T Ordered](t testing.TB, s []T) {
+	for i := 1; i < len(s); i++ {
+		if !(s[i] > s[i-1]) {
+			t.Helper()
+			t.Errorf("slice is not strictly sorted at index %d: %s", i, orderReason(s[i], s[i-1], "not greater than"))
+			return
+		}
+	}
+}
+
+// SortedBy asserts that a slice is sorted according to a less function, in the
+// same way as sort.SliceIsSorted.
+func SortedBy[T any](t testing.TB, s []T, less func(a, b T) bool) {
+	for i := 1; i < len(s); i++ {
+		if less(s[i], s[i-1]) {
+			t.Helper()
+			t.Errorf("slice is not sorted at index %d: %s sorts before %s", i, formatValue(s[i]), formatValue(s[i-1]))
+			return
+		}
+	}
+}
+
+// orderReason explains why an element is out of order with the element before
+// it. NaN is not ordered with respect to any value.
+func orderReason[T Ordered](v, prev T, relation string) string {
+	if v != v || prev != prev {
+		return fmt.Sprintf("%s is not ordered with %s", formatValue(v), formatValue(prev))
+	}
+	return fmt.Sprintf("%s is %s %s", formatValue(v), relation, formatValue(prev))
+}
@@ -0,0 +1,352 @@
+package assert
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestBetween(t *testing.T) {
+	tests := []struct {
+		name               string
+		got                float64
+		low                float64
+		high               float64
+		expectedMessage    string
+		expectedErrorCalls int
+		expectedFatalCalls int
+	}{
+		{name: "Within range", got: 3, low: 1, high: 5},
+		{name: "Equal to bounds", got: 1, low: 1, high: 1},
+		{
+			name:               "Above range",
+			got:                6,
+			low:                1,
+			high:               5,
+			expectedMessage:    "expected 6 to be in range [1, 5], but it is greater than the upper bound",
+			expectedErrorCalls: 1,
+		},
+		{
+			name:               "Below range",
+			got:                0.5,
+			low:                1,
+			high:               5,
+			expectedMessage:    "expected 0.5 to be in range [1, 5], but it is less than the lower bound",
+			expectedErrorCalls: 1,
+		},
+		{
+			name:               "NaN",
+			got:                math.NaN(),
+			low:                0,
+			high:               1,
+			expectedMessage:    "expected NaN to be in range [0, 1], but it is NaN",
+			expectedErrorCalls: 1,
+		},
+		{
+			name:               "Invalid range",
+			got:                3,
+			low:                5,
+			high:               1,
+			expectedMessage:    "invalid range [5, 1]",
+			expectedFatalCalls: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+
+			Between(mockT, tt.got, tt.low, tt.high)
+			n := len(mockT.ErrorfCalls)
+			m := len(mockT.FatalfCalls)
+
+			if n != tt.expectedErrorCalls {
+				t.Fatalf("expected %d calls to Errorf(), got %d", tt.expectedErrorCalls, n)
+			}
+
+			if m != tt.expectedFatalCalls {
+				t.Fatalf("expected %d calls to Fatalf(), got %d", tt.expectedFatalCalls, m)
+			}
+
+			if mockT.HelperCalls != n+m {
+				t.Errorf("expected %d calls to Helper(), got %d", n+m, mockT.HelperCalls)
+			}
+
+			if n > 0 && mockT.ErrorfCalls[0].message() != tt.expectedMessage {
+				t.Errorf("expected message:\n%s\ngot:\n%s", tt.expectedMessage, mockT.ErrorfCalls[0].message())
+			}
+
+			if m > 0 && mockT.FatalfCalls[0].message() != tt.expectedMessage {
+				t.Errorf("expected message:\n%s\ngot:\n%s", tt.expectedMessage, mockT.FatalfCalls[0].message())
+			}
+		})
+	}
+}
+
+func TestBetweenStrings(t *testing.T) {
+	mockT := newMockTB()
+
+	Between(mockT, "apple", "banana", "cherry")
+
+	if len(mockT.ErrorfCalls) != 1 {
+		t.Fatalf("expected 1 call to Errorf(), got %d", len(mockT.ErrorfCalls))
+	}
+
+	if mockT.HelperCalls != 1 {
+		t.Errorf("expected 1 call to Helper(), got %d", mockT.HelperCalls)
+	}
+
+	expected := `expected "apple" to be in range ["banana", "cherry"], but it is less than the lower bound`
+	if got := mockT.ErrorfCalls[0].message(); got != expected {
+		t.Errorf("expected message:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestInRange(t *testing.T) {
+	tests := []struct {
+		name               string
+		got                float64
+		low                float64
+		high               float64
+		bounds             Bounds
+		expectedMessage    string
+		expectedErrorCalls int
+		expectedFatalCalls int
+	}{
+		{name: "Inclusive", got: 5, low: 1, high: 5, bounds: Inclusive},
+		{name: "Exclusive", got: 1.5, low: 1, high: 2, bounds: Exclusive},
+		{name: "Excluded upper bound with included lower bound", got: 0, low: 0, high: 10, bounds: ExcludeHigh},
+		{
+			name:               "Excluded lower bound",
+			got:                1,
+			low:                1,
+			high:               5,
+			bounds:             ExcludeLow,
+			expectedMessage:    "expected 1 to be in range (1, 5], but it is equal to the excluded lower bound",
+			expectedErrorCalls: 1,
+		},
+		{
+			name:               "Excluded upper bound",
+			got:                5,
+			low:                1,
+			high:               5,
+			bounds:             Exclusive,
+			expectedMessage:    "expected 5 to be in range (1, 5), but it is equal to the excluded upper bound",
+			expectedErrorCalls: 1,
+		},
+		{
+			name:               "Empty exclusive range",
+			got:                1,
+			low:                1,
+			high:               1,
+			bounds:             Exclusive,
+			expectedMessage:    "expected 1 to be in range (1, 1), but it is equal to the excluded lower bound",
+			expectedErrorCalls: 1,
+		},
+		{
+			name:               "Invalid range",
+			got:                1,
+			low:                math.NaN(),
+			high:               1,
+			bounds:             ExcludeHigh,
+			expectedMessage:    "invalid range [NaN, 1)",
+			expectedFatalCalls: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+
+			InRange(mockT, tt.got, tt.low, tt.high, tt.bounds)
+			n := len(mockT.ErrorfCalls)
+			m := len(mockT.FatalfCalls)
+
+			if n != tt.expectedErrorCalls {
+				t.Fatalf("expected %d calls to Errorf(), got %d", tt.expectedErrorCalls, n)
+			}
+
+			if m != tt.expectedFatalCalls {
+				t.Fatalf("expected %d calls to Fatalf(), got %d", tt.expectedFatalCalls, m)
+			}
+
+			if mockT.HelperCalls != n+m {
+				t.Errorf("expected %d calls to Helper(), got %d", n+m, mockT.HelperCalls)
+			}
+
+			if n > 0 && mockT.ErrorfCalls[0].message() != tt.expectedMessage {
+				t.Errorf("expected message:\n%s\ngot:\n%s", tt.expectedMessage, mockT.ErrorfCalls[0].message())
+			}
+
+			if m > 0 && mockT.FatalfCalls[0].message() != tt.expectedMessage {
+				t.Errorf("expected message:\n%s\ngot:\n%s", tt.expectedMessage, mockT.FatalfCalls[0].message())
+			}
+		})
+	}
+}
+
+func TestOneOf(t *testing.T) {
+	tests := []struct {
+		name            string
+		got             string
+		allowed         []string
+		expectedMessage string
+		expectedCalls   int
+	}{
+		{name: "Allowed value", got: "b", allowed: []string{"a", "b", "c"}},
+		{
+			name:            "Disallowed value",
+			got:             "d",
+			allowed:         []string{"a", "b", "c"},
+			expectedMessage: `expected one of ["a", "b", "c"], got "d"`,
+			expectedCalls:   1,
+		},
+		{
+			name:            "No allowed values",
+			got:             "a",
+			expectedMessage: `expected one of [], got "a"`,
+			expectedCalls:   1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+
+			OneOf(mockT, tt.got, tt.allowed...)
+			n := len(mockT.ErrorfCalls)
+
+			if n != tt.expectedCalls {
+				t.Fatalf("expected %d calls to Errorf(), got %d", tt.expectedCalls, n)
+			}
+
+			if n != mockT.HelperCalls {
+				t.Errorf("expected %d calls to Helper(), got %d", n, mockT.HelperCalls)
+			}
+
+			if n > 0 && mockT.ErrorfCalls[0].message() != tt.expectedMessage {
+				t.Errorf("expected message:\n%s\ngot:\n%s", tt.expectedMessage, mockT.ErrorfCalls[0].message())
+			}
+		})
+	}
+}
+
+func TestSorted(t *testing.T) {
+	tests := []struct {
+		name            string
+		s               []float64
+		expectedMessage string
+		expectedCalls   int
+	}{
+		{name: "Sorted with duplicates", s: []float64{1, 2, 2, 3}},
+		{name: "Empty", s: []float64{}},
+		{
+			name:            "Out of order",
+			s:               []float64{1, 3, 2, 0},
+			expectedMessage: "slice is not sorted at index 2: 2 is less than 3",
+			expectedCalls:   1,
+		},
+		{
+			name:            "NaN",
+			s:               []float64{1, math.NaN(), 2},
+			expectedMessage: "slice is not sorted at index 1: NaN is not ordered with 1",
+			expectedCalls:   1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+
+			Sorted(mockT, tt.s)
+			n := len(mockT.ErrorfCalls)
+
+			if n != tt.expectedCalls {
+				t.Fatalf("expected %d calls to Errorf(), got %d", tt.expectedCalls, n)
+			}
+
+			if n != mockT.HelperCalls {
+				t.Errorf("expected %d calls to Helper(), got %d", n, mockT.HelperCalls)
+			}
+
+			if n > 0 && mockT.ErrorfCalls[0].message() != tt.expectedMessage {
+				t.Errorf("expected message:\n%s\ngot:\n%s", tt.expectedMessage, mockT.ErrorfCalls[0].message())
+			}
+		})
+	}
+}
+
+func TestStrictlySorted(t *testing.T) {
+	tests := []struct {
+		name            string
+		s               []int
+		expectedMessage string
+		expectedCalls   int
+	}{
+		{name: "Strictly sorted", s: []int{1, 2, 3}},
+		{
+			name:            "Duplicates",
+			s:               []int{1, 2, 2, 3},
+			expectedMessage: "slice is not strictly sorted at index 2: 2 is not greater than 2",
+			expectedCalls:   1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+
+			StrictlySorted(mockT, tt.s)
+			n := len(mockT.ErrorfCalls)
+
+			if n != tt.expectedCalls {
+				t.Fatalf("expected %d calls to Errorf(), got %d", tt.expectedCalls, n)
+			}
+
+			if n != mockT.HelperCalls {
+				t.Errorf("expected %d calls to Helper(), got %d", n, mockT.HelperCalls)
+			}
+
+			if n > 0 && mockT.ErrorfCalls[0].message() != tt.expectedMessage {
+				t.Errorf("expected message:\n%s\ngot:\n%s", tt.expectedMessage, mockT.ErrorfCalls[0].message())
+			}
+		})
+	}
+}
+
+func TestSortedBy(t *testing.T) {
+	byLength := func(a, b string) bool { return len(a) < len(b) }
+	byFoldedCase := func(a, b string) bool { return strings.ToLower(a) < strings.ToLower(b) }
+
+	tests := []struct {
+		name            string
+		s               []string
+		less            func(a, b string) bool
+		expectedMessage string
+		expectedCalls   int
+	}{
+		{name: "Sorted by length", s: []string{"b", "a", "cc"}, less: byLength},
+		{
+			name:            "Not sorted by folded case",
+			s:               []string{"a", "C", "b"},
+			less:            byFoldedCase,
+			expectedMessage: `slice is not sorted at index 2: "b" sorts before "C"`,
+			expectedCalls:   1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+
+			SortedBy(mockT, tt.s, tt.less)
+			n := len(mockT.ErrorfCalls)
+
+			if n != tt.expectedCalls {
+				t.Fatalf("expected %d calls to Errorf(), got %d", tt.expectedCalls, n)
+			}
+
+			if n != mockT.HelperCalls {
+				t.Errorf("expected %d calls to Helper(), got %d", n, mockT.HelperCalls)
+			}
+
+			if n > 0 && mockT.ErrorfCalls[0].message() != tt.expectedMessage {
+				t.Errorf("expected message:\n%s\ngot:\n%s", tt.expectedMessage, mockT.ErrorfCalls[0].message())
+			}
+		})
+	}
+}
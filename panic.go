@@ -0,0 +1,155 @@
+package assert
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"strings"
+	"testing"
+)
+
+// Panics asserts that the function panics.
+func Panics(t testing.TB, f func()) {
+	if _, panicked, _ := recoverPanic(f); !panicked {
+		t.Helper()
+		t.Error("expected function to panic")
+	}
+}
+
+// NotPanics asserts that the function does not panic.
+func NotPanics(t testing.TB, f func()) {
+	if value, panicked, stack := recoverPanic(f); panicked {
+		t.Helper()
+		t.Errorf("expected function to not panic, recovered %s\n%s", formatValue(value), stack)
+	}
+}
+
+// PanicsWithValue asserts that the function panics with a value that is
+// equivalent to the expected value according to reflect.DeepEqual.
+func PanicsWithValue(t testing.TB, f func(), expected any) {
+	value, panicked, stack := recoverPanic(f)
+	if !panicked {
+		t.Helper()
+		t.Errorf("expected function to panic with %s", formatValue(expected))
+		return
+	}
+
+	if diff := diffValues(expected, value); diff != "" {
+		t.Helper()
+		t.Errorf("panic value is not equal:\n%s\n%s", indent(diff, "\t"), stack)
+	}
+}
+
+// PanicsWithError asserts that the function panics with an error that wraps
+// the target error according to the semantics of errors.Is.
+func PanicsWithError(t testing.TB, f func(), target error) {
+	value, panicked, stack := recoverPanic(f)
+	if !panicked {
+		t.Helper()
+		t.Errorf(`expected function to panic with error "%v"`, target)
+		return
+	}
+
+	err, ok := value.(error)
+	if !ok {
+		t.Helper()
+		t.Errorf(`expected function to panic with error "%v", recovered non-error %s`+"\n%s", target, formatValue(value), stack)
+		return
+	}
+
+	if !errors.Is(err, target) {
+		t.Helper()
+		t.Errorf(`expected function to panic with error "%v", recovered "%v"`+"\n%s", target, err, stack)
+	}
+}
+
+// PanicsWithErrorAs asserts that the function panics with an error whose chain
+// contains an error of type E according to the semantics of errors.As. The
+// matching error is returned so that further assertions can be made on it. If
+// the assertion fails, the zero value of E is returned.
+func PanicsWithErrorAs[E error](t testing.TB, f func()) E {
+	var target E
+
+	value, panicked, stack := recoverPanic(f)
+	if !panicked {
+		t.Helper()
+		t.Errorf("expected function to panic with error of type %T", target)
+		return target
+	}
+
+	err, ok := value.(error)
+	if !ok || !errors.As(err, &target) {
+		t.Helper()
+		t.Errorf("expected function to panic with error of type %T, recovered %s\n%s", target, formatValue(value), stack)
+	}
+
+	return target
+}
+
+// PanicsMatching asserts that the function panics and that the panic message
+// is matched by the provided pattern. The message of an error is its Error
+// method, while other values are formatted with fmt.Sprint. Patterns are cached
+// in the same way as RegexMatches.
+func PanicsMatching(t testing.TB, f func(), pattern string) {
+	r, err := compileRegex(pattern)
+	if err != nil {
+		t.Helper()
+		t.Fatalf("failed to compile regular expression: %v", err)
+		return
+	}
+
+	value, panicked, stack := recoverPanic(f)
+	if !panicked {
+		t.Helper()
+		t.Errorf("expected function to panic with message matched by pattern /%s/", pattern)
+		return
+	}
+
+	msg := fmt.Sprint(value)
+	if !r.MatchString(msg) {
+		t.Helper()
+		t.Errorf("panic message %s not matched by pattern /%s/\n%s", msg, pattern, stack)
+	}
+}
+
+// recoverPanic calls f and reports whether it panicked. If it did, the
+// recovered value and the stack trace of the goroutine at the point of the
+// panic are returned.
+func recoverPanic(f func()) (value any, panicked bool, stack string) {
+	defer func() {
+		if panicked {
+			value = recover()
+			stack = panicStack(debug.Stack())
+		}
+	}()
+
+	// A panic with a nil value cannot be distinguished from no panic by the
+	// value returned from recover, so track whether f returned normally.
+	panicked = true
+	f()
+	panicked = false
+
+	return nil, false, ""
+}
+
+// panicStack trims a stack trace captured while recovering from a panic down
+// to the frames between the call to panic and the call to recoverPanic.
+func panicStack(stack []byte) string {
+	lines := strings.Split(strings.TrimSpace(string(stack)), "\n")
+
+	start, end := 1, len(lines)
+	for i, l := range lines {
+		if strings.HasPrefix(l, "panic(") {
+			// Skip the function line and the file line of the call to panic.
+			start = i + 2
+		} else if i > start && strings.Contains(l, "/assert.recoverPanic(") {
+			end = i
+			break
+		}
+	}
+
+	if start > end {
+		start = end
+	}
+	return "panic stack:\n" + strings.Join(lines[start:end], "\n")
+}
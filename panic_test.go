@@ -0,0 +1,235 @@
+package assert
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type validationError struct {
+	Field string
+}
+
+func (e *validationError) Error() string {
+	return "invalid " + e.Field
+}
+
+func panicWith(v any) func() {
+	return func() {
+		panic(v)
+	}
+}
+
+func noPanic() {}
+
+func TestPanics(t *testing.T) {
+	tests := []struct {
+		name          string
+		f             func()
+		expectedCalls int
+	}{
+		{name: "Panics", f: panicWith("boom"), expectedCalls: 0},
+		{name: "Panics with nil", f: panicWith(nil), expectedCalls: 0},
+		{name: "Does not panic", f: noPanic, expectedCalls: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+
+			Panics(mockT, tt.f)
+			n := len(mockT.ErrorCalls)
+
+			if n != tt.expectedCalls {
+				t.Errorf("expected %d calls to Error(), got %d", tt.expectedCalls, n)
+			}
+
+			if n != mockT.HelperCalls {
+				t.Errorf("expected %d calls to Helper(), got %d", tt.expectedCalls, mockT.HelperCalls)
+			}
+		})
+	}
+}
+
+func TestNotPanics(t *testing.T) {
+	tests := []struct {
+		name          string
+		f             func()
+		expectedCalls int
+	}{
+		{name: "Panics", f: panicWith("boom"), expectedCalls: 1},
+		{name: "Does not panic", f: noPanic, expectedCalls: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+
+			NotPanics(mockT, tt.f)
+			n := len(mockT.ErrorfCalls)
+
+			if n != tt.expectedCalls {
+				t.Errorf("expected %d calls to Errorf(), got %d", tt.expectedCalls, n)
+			}
+
+			if n != mockT.HelperCalls {
+				t.Errorf("expected %d calls to Helper(), got %d", tt.expectedCalls, mockT.HelperCalls)
+			}
+		})
+	}
+}
+
+func TestNotPanicsReportsStack(t *testing.T) {
+	mockT := newMockTB()
+
+	NotPanics(mockT, panicWith("boom"))
+
+	if len(mockT.ErrorfCalls) != 1 {
+		t.Fatalf("expected 1 call to Errorf(), got %d", len(mockT.ErrorfCalls))
+	}
+
+	msg := mockT.ErrorfCalls[0].message()
+	if !strings.HasPrefix(msg, "expected function to not panic, recovered \"boom\"\npanic stack:\n") {
+		t.Errorf("unexpected message:\n%s", msg)
+	}
+
+	if !strings.Contains(msg, "assert.panicWith.func1()") {
+		t.Errorf("expected stack to contain the panicking function, got:\n%s", msg)
+	}
+
+	if strings.Contains(msg, "recoverPanic") || strings.Contains(msg, "runtime/debug") {
+		t.Errorf("expected stack to be trimmed, got:\n%s", msg)
+	}
+}
+
+func TestPanicsWithValue(t *testing.T) {
+	tests := []struct {
+		name          string
+		f             func()
+		expected      any
+		expectedCalls int
+	}{
+		{name: "Equal value", f: panicWith([]int{1, 2}), expected: []int{1, 2}, expectedCalls: 0},
+		{name: "Different value", f: panicWith([]int{1, 2}), expected: []int{1, 3}, expectedCalls: 1},
+		{name: "Does not panic", f: noPanic, expected: "boom", expectedCalls: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+
+			PanicsWithValue(mockT, tt.f, tt.expected)
+			n := len(mockT.ErrorfCalls)
+
+			if n != tt.expectedCalls {
+				t.Errorf("expected %d calls to Errorf(), got %d", tt.expectedCalls, n)
+			}
+
+			if n != mockT.HelperCalls {
+				t.Errorf("expected %d calls to Helper(), got %d", tt.expectedCalls, mockT.HelperCalls)
+			}
+		})
+	}
+}
+
+func TestPanicsWithError(t *testing.T) {
+	sentinelErr := errors.New("base error")
+
+	tests := []struct {
+		name          string
+		f             func()
+		target        error
+		expectedCalls int
+	}{
+		{name: "Sentinel error", f: panicWith(sentinelErr), target: sentinelErr, expectedCalls: 0},
+		{name: "Wrapped error", f: panicWith(fmt.Errorf("wrapped: %w", sentinelErr)), target: sentinelErr, expectedCalls: 0},
+		{name: "Different error", f: panicWith(errors.New("other")), target: sentinelErr, expectedCalls: 1},
+		{name: "Non error value", f: panicWith("base error"), target: sentinelErr, expectedCalls: 1},
+		{name: "Does not panic", f: noPanic, target: sentinelErr, expectedCalls: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+
+			PanicsWithError(mockT, tt.f, tt.target)
+			n := len(mockT.ErrorfCalls)
+
+			if n != tt.expectedCalls {
+				t.Errorf("expected %d calls to Errorf(), got %d", tt.expectedCalls, n)
+			}
+
+			if n != mockT.HelperCalls {
+				t.Errorf("expected %d calls to Helper(), got %d", tt.expectedCalls, mockT.HelperCalls)
+			}
+		})
+	}
+}
+
+func TestPanicsWithErrorAs(t *testing.T) {
+	tests := []struct {
+		name          string
+		f             func()
+		expectedField string
+		expectedCalls int
+	}{
+		{name: "Typed error", f: panicWith(&validationError{Field: "name"}), expectedField: "name", expectedCalls: 0},
+		{name: "Wrapped typed error", f: panicWith(fmt.Errorf("wrapped: %w", &validationError{Field: "age"})), expectedField: "age", expectedCalls: 0},
+		{name: "Different error", f: panicWith(errors.New("other")), expectedCalls: 1},
+		{name: "Does not panic", f: noPanic, expectedCalls: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+
+			err := PanicsWithErrorAs[*validationError](mockT, tt.f)
+			n := len(mockT.ErrorfCalls)
+
+			if n != tt.expectedCalls {
+				t.Errorf("expected %d calls to Errorf(), got %d", tt.expectedCalls, n)
+			}
+
+			if n != mockT.HelperCalls {
+				t.Errorf("expected %d calls to Helper(), got %d", tt.expectedCalls, mockT.HelperCalls)
+			}
+
+			if n == 0 && err.Field != tt.expectedField {
+				t.Errorf("expected field %q, got %q", tt.expectedField, err.Field)
+			}
+		})
+	}
+}
+
+func TestPanicsMatching(t *testing.T) {
+	tests := []struct {
+		name               string
+		f                  func()
+		pattern            string
+		expectedErrorCalls int
+		expectedFatalCalls int
+	}{
+		{name: "Matching string", f: panicWith("index 7 out of range"), pattern: `index \d+`},
+		{name: "Matching error", f: panicWith(errors.New("invalid name")), pattern: `^invalid`},
+		{name: "No match", f: panicWith("boom"), pattern: `index \d+`, expectedErrorCalls: 1},
+		{name: "Does not panic", f: noPanic, pattern: `boom`, expectedErrorCalls: 1},
+		{name: "Invalid regex", f: panicWith("boom"), pattern: `\1`, expectedFatalCalls: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+
+			PanicsMatching(mockT, tt.f, tt.pattern)
+			n := len(mockT.ErrorfCalls)
+			m := len(mockT.FatalfCalls)
+
+			if n != tt.expectedErrorCalls {
+				t.Errorf("expected %d calls to Errorf(), got %d", tt.expectedErrorCalls, n)
+			}
+
+			if m != tt.expectedFatalCalls {
+				t.Errorf("expected %d calls to Fatalf(), got %d", tt.expectedFatalCalls, m)
+			}
+
+			if mockT.HelperCalls != n+m {
+				t.Errorf("expected %d calls to Helper(), got %d", n+m, mockT.HelperCalls)
+			}
+		})
+	}
+}
@@ -0,0 +1,242 @@
+package assert
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mattmeyers/assert/clock"
+)
+
+// PollOption configures how Eventually and Consistently poll their condition.
+type PollOption func(*pollConfig)
+
+// pollConfig holds the polling settings of Eventually and Consistently.
+type pollConfig struct {
+	interval    time.Duration
+	backoff     float64
+	maxInterval time.Duration
+	clock       clock.Clock
+}
+
+// defaultPollInterval is the time waited between attempts when no
+// PollInterval option is provided.
+const defaultPollInterval = 10 * time.Millisecond
+
+// PollInterval sets the time waited between calls to the condition.
+func PollInterval(d time.Duration) PollOption {
+	return func(c *pollConfig) {
+		c.interval = d
+	}
+}
+
+// PollBackoff multiplies the poll interval by factor after every attempt, up to
+// a maximum interval of max.
+func PollBackoff(factor float64, max time.Duration) PollOption {
+	return func(c *pollConfig) {
+		c.backoff = factor
+		c.maxInterval = max
+	}
+}
+
+// PollClock sets the clock used to measure the timeout and to wait between
+// attempts. A clock.FakeClock is advanced by the poll interval between attempts
+// instead of being waited on, so polling completes without delay while the
+// timers scheduled on the clock fire as they would in real time.
+func PollClock(c clock.Clock) PollOption {
+	return func(pc *pollConfig) {
+		pc.clock = c
+	}
+}
+
+func newPollConfig(opts []PollOption) *pollConfig {
+	c := &pollConfig{interval: defaultPollInterval, backoff: 1, clock: clock.Real()}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// wait waits for d to elapse on the configured clock.
+func (c *pollConfig) wait(d time.Duration) {
+	if fake, ok := c.clock.(*clock.FakeClock); ok {
+		fake.Advance(d)
+		return
+	}
+	c.clock.Sleep(d)
+}
+
+// next returns the interval to wait before the attempt following one that
+// waited d.
+func (c *pollConfig) next(d time.Duration) time.Duration {
+	if c.backoff <= 1 {
+		return d
+	}
+
+	d = time.Duration(float64(d) * c.backoff)
+	if c.maxInterval > 0 && d > c.maxInterval {
+		d = c.maxInterval
+	}
+	return d
+}
+
+// Eventually asserts that a condition passes before the timeout elapses. The
+// condition is called repeatedly with a testing.TB that records failures
+// instead of reporting them, so any assertion can be used within it. An attempt
+// passes if no failures are recorded. If the timeout elapses, the failures of
+// the last attempt are reported.
+func Eventually(t testing.TB, condition func(t testing.TB), timeout time.Duration, opts ...PollOption) {
+	c := newPollConfig(opts)
+	start := c.clock.Now()
+	deadline := start.Add(timeout)
+	interval := c.interval
+
+	for attempt := 1; ; attempt++ {
+		r := runAttempt(t, condition)
+		if !r.failed {
+			return
+		}
+
+		remaining := c.clock.Until(deadline)
+		if remaining <= 0 {
+			t.Helper()
+			t.Errorf("condition not satisfied within %v after %d attempts, last failure:\n%s",
+				timeout, attempt, indent(r.String(), "\t"))
+			return
+		}
+
+		if interval > remaining {
+			interval = remaining
+		}
+		c.wait(interval)
+		interval = c.next(interval)
+	}
+}
+
+// Consistently asserts that a condition passes every time it is called for
+// the entire duration. The condition is called repeatedly with a testing.TB
+// that records failures instead of reporting them, so any assertion can be used
+// within it. The failures of the first failed attempt are reported.
+func Consistently(t testing.TB, condition func(t testing.TB), duration time.Duration, opts ...PollOption) {
+	c := newPollConfig(opts)
+	start := c.clock.Now()
+	deadline := start.Add(duration)
+	interval := c.interval
+
+	for attempt := 1; ; attempt++ {
+		r := runAttempt(t, condition)
+		if r.failed {
+			t.Helper()
+			t.Errorf("condition failed after %v on attempt %d:\n%s",
+				c.clock.Since(start).Round(time.Millisecond), attempt, indent(r.String(), "\t"))
+			return
+		}
+
+		remaining := c.clock.Until(deadline)
+		if remaining <= 0 {
+			return
+		}
+
+		if interval > remaining {
+			interval = remaining
+		}
+		c.wait(interval)
+		interval = c.next(interval)
+	}
+}
+
+// runAttempt calls the condition once with a recordingTB. The condition is run
+// in its own goroutine so that fatal assertions, which stop the goroutine
+// calling them, only end the attempt. Panics are propagated to the caller.
+func runAttempt(t testing.TB, condition func(t testing.TB)) *recordingTB {
+	r := &recordingTB{TB: t}
+
+	var panicked any
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer func() {
+			panicked = recover()
+		}()
+
+		condition(r)
+	}()
+	<-done
+
+	if panicked != nil {
+		panic(panicked)
+	}
+
+	return r
+}
+
+// recordingTB is a testing.TB that records failures instead of reporting them.
+// Calls to FailNow, Fatal, and Fatalf stop the calling goroutine.
+type recordingTB struct {
+	testing.TB
+
+	mu     sync.Mutex
+	failed bool
+	msgs   []string
+}
+
+func (r *recordingTB) Helper() {}
+
+func (r *recordingTB) Fail() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.failed = true
+}
+
+func (r *recordingTB) Failed() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.failed
+}
+
+func (r *recordingTB) FailNow() {
+	r.Fail()
+	runtime.Goexit()
+}
+
+func (r *recordingTB) Error(args ...any) {
+	r.record(sprintln(args...))
+}
+
+func (r *recordingTB) Errorf(format string, args ...any) {
+	r.record(fmt.Sprintf(format, args...))
+}
+
+func (r *recordingTB) Fatal(args ...any) {
+	r.record(sprintln(args...))
+	r.FailNow()
+}
+
+func (r *recordingTB) Fatalf(format string, args ...any) {
+	r.record(fmt.Sprintf(format, args...))
+	r.FailNow()
+}
+
+func (r *recordingTB) record(msg string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.failed = true
+	r.msgs = append(r.msgs, msg)
+}
+
+// String returns the recorded failure messages, one per line.
+func (r *recordingTB) String() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.msgs) == 0 {
+		return "test marked as failed"
+	}
+	return strings.Join(r.msgs, "\n")
+}
@@ -0,0 +1,168 @@
+package assert
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mattmeyers/assert/clock"
+)
+
+func TestEventually(t *testing.T) {
+	tests := []struct {
+		name          string
+		passAfter     int
+		fatal         bool
+		expectedCalls int
+	}{
+		{name: "Passes immediately", passAfter: 1, expectedCalls: 0},
+		{name: "Passes eventually", passAfter: 3, expectedCalls: 0},
+		{name: "Passes eventually after fatal failures", passAfter: 3, fatal: true, expectedCalls: 0},
+		{name: "Never passes", passAfter: 1000, expectedCalls: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+			attempts := 0
+
+			Eventually(mockT, func(t testing.TB) {
+				attempts++
+				if tt.fatal {
+					t = Fatal(t)
+				}
+				GreaterThanOrEqual(t, attempts, tt.passAfter)
+			}, 50*time.Millisecond, PollInterval(time.Millisecond))
+
+			n := len(mockT.ErrorfCalls)
+			if n != tt.expectedCalls {
+				t.Errorf("expected %d calls to Errorf(), got %d", tt.expectedCalls, n)
+			}
+
+			if n != mockT.HelperCalls {
+				t.Errorf("expected %d calls to Helper(), got %d", tt.expectedCalls, mockT.HelperCalls)
+			}
+		})
+	}
+}
+
+func TestEventuallyReportsLastFailure(t *testing.T) {
+	mockT := newMockTB()
+	attempts := 0
+
+	Eventually(mockT, func(t testing.TB) {
+		attempts++
+		Equal(t, attempts, -1)
+	}, 20*time.Millisecond, PollInterval(time.Millisecond), PollBackoff(2, 4*time.Millisecond))
+
+	if len(mockT.ErrorfCalls) != 1 {
+		t.Fatalf("expected 1 call to Errorf(), got %d", len(mockT.ErrorfCalls))
+	}
+
+	msg := mockT.ErrorfCalls[0].message()
+	if !strings.HasPrefix(msg, "condition not satisfied within 20ms after ") {
+		t.Errorf("unexpected message:\n%s", msg)
+	}
+
+	if !strings.HasSuffix(msg, "last failure:\n\texpected \"-1\", got \""+strconv.Itoa(attempts)+"\"") {
+		t.Errorf("expected message to report the last attempt, got:\n%s", msg)
+	}
+}
+
+func TestConsistently(t *testing.T) {
+	tests := []struct {
+		name          string
+		failAfter     int
+		expectedCalls int
+	}{
+		{name: "Always passes", failAfter: 1000, expectedCalls: 0},
+		{name: "Fails on first attempt", failAfter: 0, expectedCalls: 1},
+		{name: "Fails on later attempt", failAfter: 3, expectedCalls: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+			attempts := 0
+
+			Consistently(mockT, func(t testing.TB) {
+				attempts++
+				LessThanOrEqual(t, attempts, tt.failAfter)
+			}, 20*time.Millisecond, PollInterval(time.Millisecond))
+
+			n := len(mockT.ErrorfCalls)
+			if n != tt.expectedCalls {
+				t.Errorf("expected %d calls to Errorf(), got %d", tt.expectedCalls, n)
+			}
+
+			if n != mockT.HelperCalls {
+				t.Errorf("expected %d calls to Helper(), got %d", tt.expectedCalls, mockT.HelperCalls)
+			}
+
+			if n == 1 && attempts != tt.failAfter+1 {
+				t.Errorf("expected polling to stop after attempt %d, got %d", tt.failAfter+1, attempts)
+			}
+		})
+	}
+}
+
+func TestEventuallyPropagatesPanics(t *testing.T) {
+	defer func() {
+		if r := recover(); r != "boom" {
+			t.Errorf(`expected panic "boom", got %v`, r)
+		}
+	}()
+
+	Eventually(newMockTB(), func(t testing.TB) {
+		panic("boom")
+	}, time.Second)
+}
+
+func TestPollClock(t *testing.T) {
+	c := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	mockT := newMockTB()
+
+	done := false
+	c.AfterFunc(3*time.Second, func() { done = true })
+
+	attempts := 0
+	Eventually(mockT, func(t testing.TB) {
+		attempts++
+		Equal(t, done, true)
+	}, time.Minute, PollInterval(time.Second), PollClock(c))
+
+	if len(mockT.ErrorfCalls) != 0 {
+		t.Fatalf("expected 0 calls to Errorf(), got %d", len(mockT.ErrorfCalls))
+	}
+
+	if attempts != 4 {
+		t.Errorf("expected 4 attempts, got %d", attempts)
+	}
+
+	if elapsed := c.Since(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)); elapsed != 3*time.Second {
+		t.Errorf("expected fake clock to advance by 3s, got %s", elapsed)
+	}
+
+	attempts = 0
+	Eventually(mockT, func(t testing.TB) {
+		attempts++
+		t.Errorf("never")
+	}, 5*time.Second, PollInterval(time.Second), PollClock(c))
+
+	if len(mockT.ErrorfCalls) != 1 {
+		t.Fatalf("expected 1 call to Errorf(), got %d", len(mockT.ErrorfCalls))
+	}
+
+	expected := "condition not satisfied within 5s after 6 attempts, last failure:\n\tnever"
+	if msg := mockT.ErrorfCalls[0].message(); msg != expected {
+		t.Errorf("expected message %q, got %q", expected, msg)
+	}
+
+	attempts = 0
+	Consistently(mockT, func(t testing.TB) {
+		attempts++
+	}, 3*time.Second, PollInterval(time.Second), PollClock(c))
+
+	if attempts != 4 {
+		t.Errorf("expected 4 attempts, got %d", attempts)
+	}
+}
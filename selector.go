@@ -0,0 +1,451 @@
+package assert
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// HTMLContainsSelector asserts that an HTML document contains an element
+// matching a CSS selector whose text content is the expected text. Text
+// content is compared with runs of whitespace collapsed into a single space
+// and leading and trailing whitespace removed. HTML is parsed in the same way
+// as HTMLEqual.
+//
+// The supported selector syntax is:
+//
+//	tag  *  #id  .class        type, universal, id, and class selectors
+//	[attr]  [attr=v]           attribute presence and equality
+//	[attr~=v]  [attr|=v]       whitespace separated word, or v and v- prefix
+//	[attr^=v]  [attr$=v]       value prefix and suffix
+//	[attr*=v]                  value substring
+//	:first-child :last-child   structural pseudo-classes
+//	:only-child :empty
+//	:nth-child(n|odd|even)
+//	:not(selector)             negation of a compound selector
+//	a b  a > b  a + b  a ~ b   descendant, child, and sibling combinators
+//	a, b                       selector lists
+func HTMLContainsSelector[T ~string | ~[]byte](t testing.TB, html T, selector, expectedText string) {
+	sel, err := parseSelector(selector)
+	if err != nil {
+		t.Helper()
+		t.Fatalf("invalid selector %q: %v", selector, err)
+		return
+	}
+
+	doc, err := parseMarkup([]byte(html), true)
+	if err != nil {
+		t.Helper()
+		t.Errorf("failed to parse HTML: %v", err)
+		return
+	}
+
+	matches := sel.selectAll(doc)
+	if len(matches) == 0 {
+		t.Helper()
+		t.Errorf("no element matches selector %q", selector)
+		return
+	}
+
+	lines := make([]string, len(matches))
+	for i, n := range matches {
+		text := n.textContent()
+		if text == expectedText {
+			return
+		}
+		lines[i] = n.xpath() + ": " + strconv.Quote(text)
+	}
+
+	t.Helper()
+	t.Errorf("no element matching %q has text %q, found:\n%s", selector, expectedText, indent(strings.Join(lines, "\n"), "\t"))
+}
+
+// cssSelector is a list of complex selectors, matching elements that match
+// any of them.
+type cssSelector []cssComplex
+
+// cssComplex is a sequence of compound selectors joined by combinators.
+// combinators[i] is the combinator between compounds[i] and compounds[i+1].
+type cssComplex struct {
+	compounds   []cssCompound
+	combinators []byte
+}
+
+// cssCompound is a list of simple selectors that must all match an element.
+type cssCompound []func(*markupNode) bool
+
+// selectAll returns the elements below n that match the selector, in document
+// order.
+func (s cssSelector) selectAll(n *markupNode) []*markupNode {
+	var matches []*markupNode
+	for _, c := range n.elements() {
+		if s.match(c) {
+			matches = append(matches, c)
+		}
+		matches = append(matches, s.selectAll(c)...)
+	}
+	return matches
+}
+
+func (s cssSelector) match(n *markupNode) bool {
+	for _, c := range s {
+		if c.matchAt(n, len(c.compounds)-1) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchAt reports whether n matches the compound selector at index i and the
+// compounds before it are matched by the elements that its combinators relate
+// to n.
+func (c cssComplex) matchAt(n *markupNode, i int) bool {
+	if !c.compounds[i].match(n) {
+		return false
+	}
+	if i == 0 {
+		return true
+	}
+
+	switch c.combinators[i-1] {
+	case '>':
+		return n.parent.isElement() && c.matchAt(n.parent, i-1)
+	case ' ':
+		for p := n.parent; p.isElement(); p = p.parent {
+			if c.matchAt(p, i-1) {
+				return true
+			}
+		}
+	case '+':
+		siblings := n.parent.elements()
+		if j := indexOfNode(siblings, n); j > 0 {
+			return c.matchAt(siblings[j-1], i-1)
+		}
+	case '~':
+		siblings := n.parent.elements()
+		for _, s := range siblings[:indexOfNode(siblings, n)] {
+			if c.matchAt(s, i-1) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (c cssCompound) match(n *markupNode) bool {
+	for _, m := range c {
+		if !m(n) {
+			return false
+		}
+	}
+	return true
+}
+
+func indexOfNode(nodes []*markupNode, n *markupNode) int {
+	for i, c := range nodes {
+		if c == n {
+			return i
+		}
+	}
+	return -1
+}
+
+// cssParser parses selectors with recursive descent.
+type cssParser struct {
+	s string
+	i int
+}
+
+func parseSelector(s string) (cssSelector, error) {
+	p := &cssParser{s: s}
+
+	var sel cssSelector
+	for {
+		p.skipSpace()
+		c, err := p.complex()
+		if err != nil {
+			return nil, err
+		}
+		sel = append(sel, c)
+
+		p.skipSpace()
+		if p.i == len(p.s) {
+			return sel, nil
+		}
+		if p.s[p.i] != ',' {
+			return nil, fmt.Errorf("unexpected %q", p.s[p.i:])
+		}
+		p.i++
+	}
+}
+
+func (p *cssParser) complex() (cssComplex, error) {
+	var c cssComplex
+	for {
+		compound, err := p.compound()
+		if err != nil {
+			return cssComplex{}, err
+		}
+		c.compounds = append(c.compounds, compound)
+
+		space := p.skipSpace()
+		if p.i == len(p.s) || p.s[p.i] == ',' {
+			return c, nil
+		}
+
+		switch comb := p.s[p.i]; comb {
+		case '>', '+', '~':
+			p.i++
+			p.skipSpace()
+			c.combinators = append(c.combinators, comb)
+		default:
+			if !space {
+				return cssComplex{}, fmt.Errorf("unexpected %q", p.s[p.i:])
+			}
+			c.combinators = append(c.combinators, ' ')
+		}
+	}
+}
+
+func (p *cssParser) compound() (cssCompound, error) {
+	var c cssCompound
+
+	if p.i < len(p.s) && p.s[p.i] == '*' {
+		p.i++
+		c = append(c, func(*markupNode) bool { return true })
+	} else if name := p.ident(); name != "" {
+		name = strings.ToLower(name)
+		c = append(c, func(n *markupNode) bool { return n.name.Local == name })
+	}
+
+	for p.i < len(p.s) {
+		var (
+			m   func(*markupNode) bool
+			err error
+		)
+
+		switch p.s[p.i] {
+		case '#':
+			p.i++
+			id := p.ident()
+			if id == "" {
+				return nil, errors.New("expected id after '#'")
+			}
+			m = attrMatcher("id", func(v string) bool { return v == id })
+		case '.':
+			p.i++
+			class := p.ident()
+			if class == "" {
+				return nil, errors.New("expected class after '.'")
+			}
+			m = attrMatcher("class", func(v string) bool { return containsWord(v, class) })
+		case '[':
+			p.i++
+			m, err = p.attribute()
+		case ':':
+			p.i++
+			m, err = p.pseudoClass()
+		default:
+			if len(c) == 0 {
+				return nil, errors.New("expected selector")
+			}
+			return c, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+		c = append(c, m)
+	}
+
+	if len(c) == 0 {
+		return nil, errors.New("expected selector")
+	}
+	return c, nil
+}
+
+// attribute parses an attribute selector following its opening bracket.
+func (p *cssParser) attribute() (func(*markupNode) bool, error) {
+	p.skipSpace()
+	name := strings.ToLower(p.ident())
+	if name == "" {
+		return nil, errors.New("expected attribute name")
+	}
+	p.skipSpace()
+
+	var op string
+	if p.i < len(p.s) && strings.IndexByte("~|^$*", p.s[p.i]) >= 0 {
+		op = p.s[p.i : p.i+1]
+		p.i++
+	}
+
+	if p.i < len(p.s) && p.s[p.i] == ']' && op == "" {
+		p.i++
+		return attrMatcher(name, func(string) bool { return true }), nil
+	}
+
+	if p.i == len(p.s) || p.s[p.i] != '=' {
+		return nil, errors.New("expected '=' or ']' in attribute selector")
+	}
+	p.i++
+	p.skipSpace()
+
+	value, err := p.value()
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipSpace()
+	if p.i == len(p.s) || p.s[p.i] != ']' {
+		return nil, errors.New("expected ']' after attribute selector")
+	}
+	p.i++
+
+	var match func(string) bool
+	switch op {
+	case "":
+		match = func(v string) bool { return v == value }
+	case "~":
+		match = func(v string) bool { return containsWord(v, value) }
+	case "|":
+		match = func(v string) bool { return v == value || strings.HasPrefix(v, value+"-") }
+	case "^":
+		match = func(v string) bool { return value != "" && strings.HasPrefix(v, value) }
+	case "$":
+		match = func(v string) bool { return value != "" && strings.HasSuffix(v, value) }
+	case "*":
+		match = func(v string) bool { return value != "" && strings.Contains(v, value) }
+	}
+	return attrMatcher(name, match), nil
+}
+
+// pseudoClass parses a pseudo-class following its colon.
+func (p *cssParser) pseudoClass() (func(*markupNode) bool, error) {
+	name := strings.ToLower(p.ident())
+	switch name {
+	case "first-child":
+		return func(n *markupNode) bool { return nthChild(n) == 1 }, nil
+	case "last-child":
+		return func(n *markupNode) bool { return nthChild(n) == len(n.parent.elements()) }, nil
+	case "only-child":
+		return func(n *markupNode) bool { return len(n.parent.elements()) == 1 }, nil
+	case "empty":
+		return func(n *markupNode) bool {
+			for _, c := range n.children {
+				if !c.isText || c.raw != "" {
+					return false
+				}
+			}
+			return true
+		}, nil
+	case "nth-child", "not":
+	default:
+		return nil, fmt.Errorf("unsupported pseudo-class %q", ":"+name)
+	}
+
+	if p.i == len(p.s) || p.s[p.i] != '(' {
+		return nil, fmt.Errorf("expected '(' after %q", ":"+name)
+	}
+	p.i++
+	p.skipSpace()
+
+	var m func(*markupNode) bool
+	if name == "not" {
+		c, err := p.compound()
+		if err != nil {
+			return nil, err
+		}
+		m = func(n *markupNode) bool { return !c.match(n) }
+	} else {
+		arg := strings.ToLower(p.ident())
+		switch arg {
+		case "odd":
+			m = func(n *markupNode) bool { return nthChild(n)%2 == 1 }
+		case "even":
+			m = func(n *markupNode) bool { return nthChild(n)%2 == 0 }
+		default:
+			i, err := strconv.Atoi(arg)
+			if err != nil || i < 1 {
+				return nil, fmt.Errorf("invalid argument %q to :nth-child", arg)
+			}
+			m = func(n *markupNode) bool { return nthChild(n) == i }
+		}
+	}
+
+	p.skipSpace()
+	if p.i == len(p.s) || p.s[p.i] != ')' {
+		return nil, fmt.Errorf("expected ')' after %q", ":"+name)
+	}
+	p.i++
+	return m, nil
+}
+
+// value parses an identifier or quoted string.
+func (p *cssParser) value() (string, error) {
+	if p.i < len(p.s) && (p.s[p.i] == '"' || p.s[p.i] == '\'') {
+		q := p.s[p.i]
+		end := strings.IndexByte(p.s[p.i+1:], q)
+		if end < 0 {
+			return "", errors.New("unterminated string")
+		}
+		v := p.s[p.i+1 : p.i+1+end]
+		p.i += end + 2
+		return v, nil
+	}
+
+	v := p.ident()
+	if v == "" {
+		return "", errors.New("expected attribute value")
+	}
+	return v, nil
+}
+
+// ident parses an identifier, returning an empty string if there is none.
+func (p *cssParser) ident() string {
+	start := p.i
+	for p.i < len(p.s) {
+		c := p.s[p.i]
+		if c != '-' && c != '_' && !isDigit(c) && !(c >= 'a' && c <= 'z') && !(c >= 'A' && c <= 'Z') && c < 0x80 {
+			break
+		}
+		p.i++
+	}
+	return p.s[start:p.i]
+}
+
+// skipSpace skips whitespace and reports whether there was any.
+func (p *cssParser) skipSpace() bool {
+	start := p.i
+	for p.i < len(p.s) && strings.IndexByte(" \t\n\r\f", p.s[p.i]) >= 0 {
+		p.i++
+	}
+	return p.i > start
+}
+
+// attrMatcher returns a matcher for elements that have the named attribute
+// with a value accepted by match.
+func attrMatcher(name string, match func(string) bool) func(*markupNode) bool {
+	return func(n *markupNode) bool {
+		v, ok := n.attr(name)
+		return ok && match(v)
+	}
+}
+
+// containsWord reports whether word is one of the whitespace separated words
+// of s.
+func containsWord(s, word string) bool {
+	for _, w := range strings.Fields(s) {
+		if w == word {
+			return true
+		}
+	}
+	return false
+}
+
+// nthChild returns the one-based position of an element among the elements
+// of its parent.
+func nthChild(n *markupNode) int {
+	return indexOfNode(n.parent.elements(), n) + 1
+}
@@ -0,0 +1,139 @@
+package assert
+
+import (
+	"reflect"
+	"testing"
+)
+
+const selectorDocument = `<html>
+<body>
+  <div class="alert error" id="first">
+    <p>Something   went
+      <b>wrong</b></p>
+    <p lang="en-US">Try again</p>
+  </div>
+  <div class="alert">
+    <p>All good</p>
+  </div>
+  <ul>
+    <li><a href="https://example.com/a.pdf">A</a></li>
+    <li><a href="/b">B</a></li>
+    <li></li>
+  </ul>
+</body>
+</html>`
+
+func TestHTMLContainsSelector(t *testing.T) {
+	tests := []struct {
+		name               string
+		selector           string
+		expectedText       string
+		expectedMessage    string
+		expectedErrorCalls int
+		expectedFatalCalls int
+	}{
+		{
+			name:         "Collapsed whitespace",
+			selector:     "div.alert > p",
+			expectedText: "Something went wrong",
+		},
+		{
+			name:         "Any matching element",
+			selector:     "div.alert > p",
+			expectedText: "All good",
+		},
+		{
+			name:               "No matching text",
+			selector:           "div.error p",
+			expectedText:       "All good",
+			expectedMessage:    "no element matching \"div.error p\" has text \"All good\", found:\n\t/html/body/div[1]/p[1]: \"Something went wrong\"\n\t/html/body/div[1]/p[2]: \"Try again\"",
+			expectedErrorCalls: 1,
+		},
+		{
+			name:               "No matching element",
+			selector:           "div.warning",
+			expectedMessage:    "no element matches selector \"div.warning\"",
+			expectedErrorCalls: 1,
+		},
+		{
+			name:               "Invalid selector",
+			selector:           "div >",
+			expectedFatalCalls: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+
+			HTMLContainsSelector(mockT, selectorDocument, tt.selector, tt.expectedText)
+			n := len(mockT.ErrorfCalls)
+			m := len(mockT.FatalfCalls)
+
+			if n != tt.expectedErrorCalls {
+				t.Fatalf("expected %d calls to Errorf(), got %d", tt.expectedErrorCalls, n)
+			}
+
+			if m != tt.expectedFatalCalls {
+				t.Errorf("expected %d calls to Fatalf(), got %d", tt.expectedFatalCalls, m)
+			}
+
+			if mockT.HelperCalls != n+m {
+				t.Errorf("expected %d calls to Helper(), got %d", n+m, mockT.HelperCalls)
+			}
+
+			if tt.expectedMessage != "" && mockT.ErrorfCalls[0].message() != tt.expectedMessage {
+				t.Errorf("expected message:\n%s\ngot:\n%s", tt.expectedMessage, mockT.ErrorfCalls[0].message())
+			}
+		})
+	}
+}
+
+func TestSelectAll(t *testing.T) {
+	doc, err := parseMarkup([]byte(selectorDocument), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		selector string
+		expected []string
+	}{
+		{"#first > p:first-child", []string{"/html/body/div[1]/p[1]"}},
+		{"DIV:not(.error) p", []string{"/html/body/div[2]/p"}},
+		{"p + p", []string{"/html/body/div[1]/p[2]"}},
+		{"div ~ ul", []string{"/html/body/ul"}},
+		{"[lang|=en]", []string{"/html/body/div[1]/p[2]"}},
+		{"[class~=error], b", []string{"/html/body/div[1]", "/html/body/div[1]/p[1]/b"}},
+		{"a[href^='https'][href$=\".pdf\"]", []string{"/html/body/ul/li[1]/a"}},
+		{"a[href*=b]", []string{"/html/body/ul/li[2]/a"}},
+		{"li:nth-child(even) a", []string{"/html/body/ul/li[2]/a"}},
+		{"li:last-child:empty", []string{"/html/body/ul/li[3]"}},
+		{"body > *:only-child", nil},
+		{"div *", []string{"/html/body/div[1]/p[1]", "/html/body/div[1]/p[1]/b", "/html/body/div[1]/p[2]", "/html/body/div[2]/p"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			sel, err := parseSelector(tt.selector)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got []string
+			for _, n := range sel.selectAll(doc) {
+				got = append(got, n.xpath())
+			}
+
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestParseSelectorErrors(t *testing.T) {
+	for _, selector := range []string{"", "div,", ".", "#", "[", "[a", "[a=]", "[a='b", ":hover", ":nth-child(x)", ":not(p", "a >"} {
+		if _, err := parseSelector(selector); err == nil {
+			t.Errorf("expected error for %q", selector)
+		}
+	}
+}
@@ -0,0 +1,290 @@
+package assert
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// SliceContains asserts that a slice contains one or more values. The missing
+// values are reported together.
+func SliceContains[T comparable](t testing.TB, slice []T, values ...T) {
+	t.Helper()
+
+	var missing []string
+	for _, v := range values {
+		if sliceIndex(slice, v) < 0 {
+			missing = append(missing, formatValue(v))
+		}
+	}
+
+	if len(missing) > 0 {
+		t.Errorf("slice %s does not contain %s", formatValue(slice), strings.Join(missing, ", "))
+	}
+}
+
+// SliceNotContains asserts that a slice contains none of the values. Each
+// value that is found is reported separately.
+func SliceNotContains[T comparable](t testing.TB, slice []T, values ...T) {
+	for _, v := range values {
+		if i := sliceIndex(slice, v); i >= 0 {
+			t.Helper()
+			t.Errorf("slice %s contains %s at index %d", formatValue(slice), formatValue(v), i)
+		}
+	}
+}
+
+// SliceContainsAny asserts that a slice contains at least one of the values.
+func SliceContainsAny[T comparable](t testing.TB, slice []T, values ...T) {
+	for _, v := range values {
+		if sliceIndex(slice, v) >= 0 {
+			return
+		}
+	}
+
+	t.Helper()
+	t.Errorf("slice %s contains none of %s", formatValue(slice), formatValue(values))
+}
+
+// SliceContainsSubsequence asserts that a slice contains the elements of a
+// subsequence in the same order, though not necessarily next to each other.
+func SliceContainsSubsequence[T comparable](t testing.TB, slice, subsequence []T) {
+	start := 0
+	for _, v := range subsequence {
+		i := sliceIndex(slice[start:], v)
+		if i < 0 {
+			t.Helper()
+			if start == 0 {
+				t.Errorf("slice %s does not contain subsequence %s: %s not found", formatValue(slice), formatValue(subsequence), formatValue(v))
+			} else {
+				t.Errorf("slice %s does not contain subsequence %s: %s not found after index %d", formatValue(slice), formatValue(subsequence), formatValue(v), start-1)
+			}
+			return
+		}
+		start += i + 1
+	}
+}
+
+// SliceHasPrefix asserts that a slice starts with the elements of a prefix.
+func SliceHasPrefix[T comparable](t testing.TB, slice, prefix []T) {
+	if len(slice) < len(prefix) {
+		t.Helper()
+		t.Errorf("slice %s is shorter than prefix %s", formatValue(slice), formatValue(prefix))
+		return
+	}
+
+	for i, v := range prefix {
+		if slice[i] != v {
+			t.Helper()
+			t.Errorf("slice %s does not have prefix %s, differs at index %d", formatValue(slice), formatValue(prefix), i)
+			return
+		}
+	}
+}
+
+// SliceHasSuffix asserts that a slice ends with the elements of a suffix.
+func SliceHasSuffix[T comparable](t testing.TB, slice, suffix []T) {
+	if len(slice) < len(suffix) {
+		t.Helper()
+		t.Errorf("slice %s is shorter than suffix %s", formatValue(slice), formatValue(suffix))
+		return
+	}
+
+	offset := len(slice) - len(suffix)
+	for i, v := range suffix {
+		if slice[offset+i] != v {
+			t.Helper()
+			t.Errorf("slice %s does not have suffix %s, differs at index %d", formatValue(slice), formatValue(suffix), offset+i)
+			return
+		}
+	}
+}
+
+// SliceLen asserts that a slice has a given length.
+func SliceLen[T any](t testing.TB, slice []T, length int) {
+	if len(slice) != length {
+		t.Helper()
+		t.Errorf("expected slice of length %d, got length %d: %s", length, len(slice), formatValue(slice))
+	}
+}
+
+// SliceEmpty asserts that a slice is nil or has no elements.
+func SliceEmpty[T any](t testing.TB, slice []T) {
+	if len(slice) != 0 {
+		t.Helper()
+		t.Errorf("expected empty slice, got %s", formatValue(slice))
+	}
+}
+
+// SliceNotEmpty asserts that a slice has at least one element.
+func SliceNotEmpty[T any](t testing.TB, slice []T) {
+	if len(slice) == 0 {
+		t.Helper()
+		t.Errorf("expected non-empty slice, got %s", formatValue(slice))
+	}
+}
+
+// SliceUnique asserts that a slice contains no duplicate elements. On failure,
+// every duplicated element is reported with the indexes at which it occurs.
+func SliceUnique[T comparable](t testing.TB, slice []T) {
+	var order []T
+	indexes := make(map[T][]int, len(slice))
+	for i, v := range slice {
+		if _, ok := indexes[v]; !ok {
+			order = append(order, v)
+		}
+		indexes[v] = append(indexes[v], i)
+	}
+
+	var duplicates []string
+	for _, v := range order {
+		if is := indexes[v]; len(is) > 1 {
+			duplicates = append(duplicates, fmt.Sprintf("%s at indexes %s", formatValue(v), joinInts(is)))
+		}
+	}
+
+	if len(duplicates) > 0 {
+		t.Helper()
+		t.Errorf("slice has duplicate elements:\n%s", indent(strings.Join(duplicates, "\n"), "\t"))
+	}
+}
+
+// AllMatch asserts that every element of a slice satisfies a predicate. The
+// first element that does not is reported.
+func AllMatch[T any](t testing.TB, slice []T, predicate func(T) bool) {
+	for i, v := range slice {
+		if !predicate(v) {
+			t.Helper()
+			t.Errorf("expected every element to match, but element at index %d does not: %s", i, formatValue(v))
+			return
+		}
+	}
+}
+
+// AnyMatch asserts that at least one element of a slice satisfies a predicate.
+func AnyMatch[T any](t testing.TB, slice []T, predicate func(T) bool) {
+	for _, v := range slice {
+		if predicate(v) {
+			return
+		}
+	}
+
+	t.Helper()
+	t.Errorf("expected an element to match, but none of %s do", formatValue(slice))
+}
+
+// NoneMatch asserts that no element of a slice satisfies a predicate. The
+// first element that does is reported.
+func NoneMatch[T any](t testing.TB, slice []T, predicate func(T) bool) {
+	for i, v := range slice {
+		if predicate(v) {
+			t.Helper()
+			t.Errorf("expected no element to match, but element at index %d does: %s", i, formatValue(v))
+			return
+		}
+	}
+}
+
+// sliceIndex returns the index of the first occurrence of a value in a slice,
+// or -1 if it is not present.
+func sliceIndex[T comparable](slice []T, value T) int {
+	for i, s := range slice {
+		if s == value {
+			return i
+		}
+	}
+	return -1
+}
+
+// joinInts renders a list of integers separated by commas.
+func joinInts(is []int) string {
+	s := make([]string, len(is))
+	for i, n := range is {
+		s[i] = strconv.Itoa(n)
+	}
+	return strings.Join(s, ", ")
+}
+
+// ElementsMatch asserts that two slices contain the same elements, including
+// duplicates, in any order. On failure, the missing and extra elements are
+// reported with their counts.
+func ElementsMatch[T comparable](t testing.TB, got, expected []T) {
+	var counts []elementCount[T]
+	index := make(map[T]int, len(expected))
+	count := func(v T) *elementCount[T] {
+		i, ok := index[v]
+		if !ok {
+			i = len(counts)
+			index[v] = i
+			counts = append(counts, elementCount[T]{value: v})
+		}
+		return &counts[i]
+	}
+
+	for _, v := range expected {
+		count(v).expected++
+	}
+	for _, v := range got {
+		count(v).got++
+	}
+
+	if report := elementsReport(counts); report != "" {
+		t.Helper()
+		t.Errorf("elements do not match:\n%s", indent(report, "\t"))
+	}
+}
+
+// DeepElementsMatch asserts that two slices contain deeply equal elements,
+// including duplicates, in any order. Unlike ElementsMatch, elements are
+// compared with reflect.DeepEqual, so every pair of elements may be compared.
+func DeepElementsMatch[T any](t testing.TB, got, expected []T) {
+	var counts []elementCount[T]
+	count := func(v T) *elementCount[T] {
+		for i := range counts {
+			if reflect.DeepEqual(counts[i].value, v) {
+				return &counts[i]
+			}
+		}
+		counts = append(counts, elementCount[T]{value: v})
+		return &counts[len(counts)-1]
+	}
+
+	for _, v := range expected {
+		count(v).expected++
+	}
+	for _, v := range got {
+		count(v).got++
+	}
+
+	if report := elementsReport(counts); report != "" {
+		t.Helper()
+		t.Errorf("elements do not match:\n%s", indent(report, "\t"))
+	}
+}
+
+// elementCount counts the occurrences of an element in the expected and got
+// slices.
+type elementCount[T any] struct {
+	value    T
+	expected int
+	got      int
+}
+
+// elementsReport lists the elements that occur fewer times than expected,
+// followed by those that occur more times, one per line. An empty string is
+// returned if every count matches.
+func elementsReport[T any](counts []elementCount[T]) string {
+	var missing, extra []string
+	for _, c := range counts {
+		line := fmt.Sprintf("%s (expected %d, got %d)", formatValue(c.value), c.expected, c.got)
+		switch {
+		case c.got < c.expected:
+			missing = append(missing, "missing "+line)
+		case c.got > c.expected:
+			extra = append(extra, "extra "+line)
+		}
+	}
+	return strings.Join(append(missing, extra...), "\n")
+}
@@ -0,0 +1,652 @@
+package assert
+
+import (
+	"math"
+	"testing"
+)
+
+func TestElementsMatch(t *testing.T) {
+	tests := []struct {
+		name            string
+		got             []string
+		expected        []string
+		expectedMessage string
+		expectedCalls   int
+	}{
+		{name: "Same order", got: []string{"a", "b", "c"}, expected: []string{"a", "b", "c"}},
+		{name: "Different order with duplicates", got: []string{"b", "a", "b"}, expected: []string{"b", "b", "a"}},
+		{name: "Nil and empty", got: nil, expected: []string{}},
+		{
+			name:            "Missing and extra elements",
+			got:             []string{"d", "a", "b"},
+			expected:        []string{"a", "b", "c", "a"},
+			expectedMessage: "elements do not match:\n\tmissing \"a\" (expected 2, got 1)\n\tmissing \"c\" (expected 1, got 0)\n\textra \"d\" (expected 0, got 1)",
+			expectedCalls:   1,
+		},
+		{
+			name:            "Duplicate count differs",
+			got:             []string{"a", "a", "a", "b"},
+			expected:        []string{"a", "b"},
+			expectedMessage: "elements do not match:\n\textra \"a\" (expected 1, got 3)",
+			expectedCalls:   1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+
+			ElementsMatch(mockT, tt.got, tt.expected)
+			n := len(mockT.ErrorfCalls)
+
+			if n != tt.expectedCalls {
+				t.Fatalf("expected %d calls to Errorf(), got %d", tt.expectedCalls, n)
+			}
+
+			if n != mockT.HelperCalls {
+				t.Errorf("expected %d calls to Helper(), got %d", n, mockT.HelperCalls)
+			}
+
+			if n > 0 && mockT.ErrorfCalls[0].message() != tt.expectedMessage {
+				t.Errorf("expected message:\n%s\ngot:\n%s", tt.expectedMessage, mockT.ErrorfCalls[0].message())
+			}
+		})
+	}
+}
+
+func TestElementsMatchNaN(t *testing.T) {
+	mockT := newMockTB()
+	nan := math.NaN()
+
+	ElementsMatch(mockT, []float64{nan}, []float64{nan})
+
+	if len(mockT.ErrorfCalls) != 1 {
+		t.Fatalf("expected 1 call to Errorf(), got %d", len(mockT.ErrorfCalls))
+	}
+
+	if mockT.HelperCalls != 1 {
+		t.Errorf("expected 1 call to Helper(), got %d", mockT.HelperCalls)
+	}
+
+	expected := "elements do not match:\n\tmissing NaN (expected 1, got 0)\n\textra NaN (expected 0, got 1)"
+	if got := mockT.ErrorfCalls[0].message(); got != expected {
+		t.Errorf("expected message:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestDeepElementsMatch(t *testing.T) {
+	tests := []struct {
+		name            string
+		got             [][]int
+		expected        [][]int
+		expectedMessage string
+		expectedCalls   int
+	}{
+		{name: "Different order with duplicates", got: [][]int{{2}, {1}, {2}}, expected: [][]int{{2}, {2}, {1}}},
+		{name: "Different order", got: [][]int{{2}, {1, 2}}, expected: [][]int{{1, 2}, {2}}},
+		{
+			name:            "Missing and extra elements",
+			got:             [][]int{{1}, {1}},
+			expected:        [][]int{{1}, {2}},
+			expectedMessage: "elements do not match:\n\tmissing []int{2} (expected 1, got 0)\n\textra []int{1} (expected 1, got 2)",
+			expectedCalls:   1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+
+			DeepElementsMatch(mockT, tt.got, tt.expected)
+			n := len(mockT.ErrorfCalls)
+
+			if n != tt.expectedCalls {
+				t.Fatalf("expected %d calls to Errorf(), got %d", tt.expectedCalls, n)
+			}
+
+			if n != mockT.HelperCalls {
+				t.Errorf("expected %d calls to Helper(), got %d", n, mockT.HelperCalls)
+			}
+
+			if n > 0 && mockT.ErrorfCalls[0].message() != tt.expectedMessage {
+				t.Errorf("expected message:\n%s\ngot:\n%s", tt.expectedMessage, mockT.ErrorfCalls[0].message())
+			}
+		})
+	}
+}
+
+func TestSliceContainsMessages(t *testing.T) {
+	tests := []struct {
+		name            string
+		slice           []int
+		values          []int
+		expectedMessage string
+		expectedCalls   int
+	}{
+		{name: "Contains values", slice: []int{1, 2, 3}, values: []int{3, 1}},
+		{
+			name:            "Missing value",
+			slice:           []int{1, 2, 3},
+			values:          []int{2, 4},
+			expectedMessage: "slice []int{1, 2, 3} does not contain 4",
+			expectedCalls:   1,
+		},
+		{
+			name:            "Missing values reported together",
+			slice:           []int{1, 2},
+			values:          []int{3, 2, 4},
+			expectedMessage: "slice []int{1, 2} does not contain 3, 4",
+			expectedCalls:   1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+
+			SliceContains(mockT, tt.slice, tt.values...)
+			n := len(mockT.ErrorfCalls)
+
+			if n != tt.expectedCalls {
+				t.Fatalf("expected %d calls to Errorf(), got %d", tt.expectedCalls, n)
+			}
+
+			if mockT.HelperCalls != 1 {
+				t.Errorf("expected 1 call to Helper(), got %d", mockT.HelperCalls)
+			}
+
+			if n > 0 && mockT.ErrorfCalls[0].message() != tt.expectedMessage {
+				t.Errorf("expected message:\n%s\ngot:\n%s", tt.expectedMessage, mockT.ErrorfCalls[0].message())
+			}
+		})
+	}
+}
+
+func TestSliceNotContains(t *testing.T) {
+	tests := []struct {
+		name            string
+		slice           []int
+		values          []int
+		expectedMessage string
+		expectedCalls   int
+	}{
+		{name: "Contains none", slice: []int{1, 2, 3}, values: []int{4, 5}},
+		{
+			name:            "Contains value",
+			slice:           []int{1, 2, 3, 2},
+			values:          []int{4, 2},
+			expectedMessage: "slice []int{1, 2, 3, 2} contains 2 at index 1",
+			expectedCalls:   1,
+		},
+		{
+			name:            "Contains values reported separately",
+			slice:           []int{1, 2, 3},
+			values:          []int{3, 1},
+			expectedMessage: "slice []int{1, 2, 3} contains 3 at index 2",
+			expectedCalls:   2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+
+			SliceNotContains(mockT, tt.slice, tt.values...)
+			n := len(mockT.ErrorfCalls)
+
+			if n != tt.expectedCalls {
+				t.Fatalf("expected %d calls to Errorf(), got %d", tt.expectedCalls, n)
+			}
+
+			if n != mockT.HelperCalls {
+				t.Errorf("expected %d calls to Helper(), got %d", n, mockT.HelperCalls)
+			}
+
+			if n > 0 && mockT.ErrorfCalls[0].message() != tt.expectedMessage {
+				t.Errorf("expected message:\n%s\ngot:\n%s", tt.expectedMessage, mockT.ErrorfCalls[0].message())
+			}
+		})
+	}
+}
+
+func TestSliceContainsAny(t *testing.T) {
+	tests := []struct {
+		name            string
+		slice           []int
+		values          []int
+		expectedMessage string
+		expectedCalls   int
+	}{
+		{name: "Contains one", slice: []int{1, 2, 3}, values: []int{4, 3}},
+		{
+			name:            "Contains none",
+			slice:           []int{1, 2, 3},
+			values:          []int{4, 5},
+			expectedMessage: "slice []int{1, 2, 3} contains none of []int{4, 5}",
+			expectedCalls:   1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+
+			SliceContainsAny(mockT, tt.slice, tt.values...)
+			n := len(mockT.ErrorfCalls)
+
+			if n != tt.expectedCalls {
+				t.Fatalf("expected %d calls to Errorf(), got %d", tt.expectedCalls, n)
+			}
+
+			if n != mockT.HelperCalls {
+				t.Errorf("expected %d calls to Helper(), got %d", n, mockT.HelperCalls)
+			}
+
+			if n > 0 && mockT.ErrorfCalls[0].message() != tt.expectedMessage {
+				t.Errorf("expected message:\n%s\ngot:\n%s", tt.expectedMessage, mockT.ErrorfCalls[0].message())
+			}
+		})
+	}
+}
+
+func TestSliceContainsSubsequence(t *testing.T) {
+	tests := []struct {
+		name            string
+		slice           []int
+		subsequence     []int
+		expectedMessage string
+		expectedCalls   int
+	}{
+		{name: "Non-contiguous subsequence", slice: []int{1, 2, 3, 4}, subsequence: []int{1, 3, 4}},
+		{name: "Empty subsequence", slice: []int{1}, subsequence: nil},
+		{
+			name:            "Out of order",
+			slice:           []int{1, 2, 3},
+			subsequence:     []int{2, 1},
+			expectedMessage: "slice []int{1, 2, 3} does not contain subsequence []int{2, 1}: 1 not found after index 1",
+			expectedCalls:   1,
+		},
+		{
+			name:            "Missing first element",
+			slice:           []int{1, 2, 3},
+			subsequence:     []int{4},
+			expectedMessage: "slice []int{1, 2, 3} does not contain subsequence []int{4}: 4 not found",
+			expectedCalls:   1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+
+			SliceContainsSubsequence(mockT, tt.slice, tt.subsequence)
+			n := len(mockT.ErrorfCalls)
+
+			if n != tt.expectedCalls {
+				t.Fatalf("expected %d calls to Errorf(), got %d", tt.expectedCalls, n)
+			}
+
+			if n != mockT.HelperCalls {
+				t.Errorf("expected %d calls to Helper(), got %d", n, mockT.HelperCalls)
+			}
+
+			if n > 0 && mockT.ErrorfCalls[0].message() != tt.expectedMessage {
+				t.Errorf("expected message:\n%s\ngot:\n%s", tt.expectedMessage, mockT.ErrorfCalls[0].message())
+			}
+		})
+	}
+}
+
+func TestSliceHasPrefix(t *testing.T) {
+	tests := []struct {
+		name            string
+		slice           []int
+		prefix          []int
+		expectedMessage string
+		expectedCalls   int
+	}{
+		{name: "Has prefix", slice: []int{1, 2, 3}, prefix: []int{1, 2}},
+		{
+			name:            "Different prefix",
+			slice:           []int{1, 2, 3},
+			prefix:          []int{1, 3},
+			expectedMessage: "slice []int{1, 2, 3} does not have prefix []int{1, 3}, differs at index 1",
+			expectedCalls:   1,
+		},
+		{
+			name:            "Shorter than prefix",
+			slice:           []int{1},
+			prefix:          []int{1, 2},
+			expectedMessage: "slice []int{1} is shorter than prefix []int{1, 2}",
+			expectedCalls:   1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+
+			SliceHasPrefix(mockT, tt.slice, tt.prefix)
+			n := len(mockT.ErrorfCalls)
+
+			if n != tt.expectedCalls {
+				t.Fatalf("expected %d calls to Errorf(), got %d", tt.expectedCalls, n)
+			}
+
+			if n != mockT.HelperCalls {
+				t.Errorf("expected %d calls to Helper(), got %d", n, mockT.HelperCalls)
+			}
+
+			if n > 0 && mockT.ErrorfCalls[0].message() != tt.expectedMessage {
+				t.Errorf("expected message:\n%s\ngot:\n%s", tt.expectedMessage, mockT.ErrorfCalls[0].message())
+			}
+		})
+	}
+}
+
+func TestSliceHasSuffix(t *testing.T) {
+	tests := []struct {
+		name            string
+		slice           []int
+		suffix          []int
+		expectedMessage string
+		expectedCalls   int
+	}{
+		{name: "Has suffix", slice: []int{1, 2, 3}, suffix: []int{2, 3}},
+		{
+			name:            "Different suffix",
+			slice:           []int{1, 2, 3},
+			suffix:          []int{1, 3},
+			expectedMessage: "slice []int{1, 2, 3} does not have suffix []int{1, 3}, differs at index 1",
+			expectedCalls:   1,
+		},
+		{
+			name:            "Shorter than suffix",
+			slice:           nil,
+			suffix:          []int{3},
+			expectedMessage: "slice []int(nil) is shorter than suffix []int{3}",
+			expectedCalls:   1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+
+			SliceHasSuffix(mockT, tt.slice, tt.suffix)
+			n := len(mockT.ErrorfCalls)
+
+			if n != tt.expectedCalls {
+				t.Fatalf("expected %d calls to Errorf(), got %d", tt.expectedCalls, n)
+			}
+
+			if n != mockT.HelperCalls {
+				t.Errorf("expected %d calls to Helper(), got %d", n, mockT.HelperCalls)
+			}
+
+			if n > 0 && mockT.ErrorfCalls[0].message() != tt.expectedMessage {
+				t.Errorf("expected message:\n%s\ngot:\n%s", tt.expectedMessage, mockT.ErrorfCalls[0].message())
+			}
+		})
+	}
+}
+
+func TestSliceLen(t *testing.T) {
+	tests := []struct {
+		name            string
+		slice           []int
+		length          int
+		expectedMessage string
+		expectedCalls   int
+	}{
+		{name: "Length", slice: []int{1, 2}, length: 2},
+		{
+			name:            "Different length",
+			slice:           []int{1, 2},
+			length:          3,
+			expectedMessage: "expected slice of length 3, got length 2: []int{1, 2}",
+			expectedCalls:   1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+
+			SliceLen(mockT, tt.slice, tt.length)
+			n := len(mockT.ErrorfCalls)
+
+			if n != tt.expectedCalls {
+				t.Fatalf("expected %d calls to Errorf(), got %d", tt.expectedCalls, n)
+			}
+
+			if n != mockT.HelperCalls {
+				t.Errorf("expected %d calls to Helper(), got %d", n, mockT.HelperCalls)
+			}
+
+			if n > 0 && mockT.ErrorfCalls[0].message() != tt.expectedMessage {
+				t.Errorf("expected message:\n%s\ngot:\n%s", tt.expectedMessage, mockT.ErrorfCalls[0].message())
+			}
+		})
+	}
+}
+
+func TestSliceEmpty(t *testing.T) {
+	tests := []struct {
+		name            string
+		slice           []string
+		expectedMessage string
+		expectedCalls   int
+	}{
+		{name: "Nil", slice: nil},
+		{name: "Empty", slice: []string{}},
+		{
+			name:            "Not empty",
+			slice:           []string{"a"},
+			expectedMessage: `expected empty slice, got []string{"a"}`,
+			expectedCalls:   1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+
+			SliceEmpty(mockT, tt.slice)
+			n := len(mockT.ErrorfCalls)
+
+			if n != tt.expectedCalls {
+				t.Fatalf("expected %d calls to Errorf(), got %d", tt.expectedCalls, n)
+			}
+
+			if n != mockT.HelperCalls {
+				t.Errorf("expected %d calls to Helper(), got %d", n, mockT.HelperCalls)
+			}
+
+			if n > 0 && mockT.ErrorfCalls[0].message() != tt.expectedMessage {
+				t.Errorf("expected message:\n%s\ngot:\n%s", tt.expectedMessage, mockT.ErrorfCalls[0].message())
+			}
+		})
+	}
+}
+
+func TestSliceNotEmpty(t *testing.T) {
+	tests := []struct {
+		name            string
+		slice           []int
+		expectedMessage string
+		expectedCalls   int
+	}{
+		{name: "Not empty", slice: []int{0}},
+		{
+			name:            "Empty",
+			slice:           []int{},
+			expectedMessage: "expected non-empty slice, got []int{}",
+			expectedCalls:   1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+
+			SliceNotEmpty(mockT, tt.slice)
+			n := len(mockT.ErrorfCalls)
+
+			if n != tt.expectedCalls {
+				t.Fatalf("expected %d calls to Errorf(), got %d", tt.expectedCalls, n)
+			}
+
+			if n != mockT.HelperCalls {
+				t.Errorf("expected %d calls to Helper(), got %d", n, mockT.HelperCalls)
+			}
+
+			if n > 0 && mockT.ErrorfCalls[0].message() != tt.expectedMessage {
+				t.Errorf("expected message:\n%s\ngot:\n%s", tt.expectedMessage, mockT.ErrorfCalls[0].message())
+			}
+		})
+	}
+}
+
+func TestSliceUnique(t *testing.T) {
+	tests := []struct {
+		name            string
+		slice           []string
+		expectedMessage string
+		expectedCalls   int
+	}{
+		{name: "Unique", slice: []string{"a", "b", "c"}},
+		{
+			name:            "Duplicates",
+			slice:           []string{"b", "a", "b", "c", "a", "b"},
+			expectedMessage: "slice has duplicate elements:\n\t\"b\" at indexes 0, 2, 5\n\t\"a\" at indexes 1, 4",
+			expectedCalls:   1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+
+			SliceUnique(mockT, tt.slice)
+			n := len(mockT.ErrorfCalls)
+
+			if n != tt.expectedCalls {
+				t.Fatalf("expected %d calls to Errorf(), got %d", tt.expectedCalls, n)
+			}
+
+			if n != mockT.HelperCalls {
+				t.Errorf("expected %d calls to Helper(), got %d", n, mockT.HelperCalls)
+			}
+
+			if n > 0 && mockT.ErrorfCalls[0].message() != tt.expectedMessage {
+				t.Errorf("expected message:\n%s\ngot:\n%s", tt.expectedMessage, mockT.ErrorfCalls[0].message())
+			}
+		})
+	}
+}
+
+func TestAllMatch(t *testing.T) {
+	even := func(n int) bool { return n%2 == 0 }
+
+	tests := []struct {
+		name            string
+		slice           []int
+		expectedMessage string
+		expectedCalls   int
+	}{
+		{name: "All match", slice: []int{2, 4}},
+		{
+			name:            "Not all match",
+			slice:           []int{2, 3, 5},
+			expectedMessage: "expected every element to match, but element at index 1 does not: 3",
+			expectedCalls:   1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+
+			AllMatch(mockT, tt.slice, even)
+			n := len(mockT.ErrorfCalls)
+
+			if n != tt.expectedCalls {
+				t.Fatalf("expected %d calls to Errorf(), got %d", tt.expectedCalls, n)
+			}
+
+			if n != mockT.HelperCalls {
+				t.Errorf("expected %d calls to Helper(), got %d", n, mockT.HelperCalls)
+			}
+
+			if n > 0 && mockT.ErrorfCalls[0].message() != tt.expectedMessage {
+				t.Errorf("expected message:\n%s\ngot:\n%s", tt.expectedMessage, mockT.ErrorfCalls[0].message())
+			}
+		})
+	}
+}
+
+func TestAnyMatch(t *testing.T) {
+	even := func(n int) bool { return n%2 == 0 }
+
+	tests := []struct {
+		name            string
+		slice           []int
+		expectedMessage string
+		expectedCalls   int
+	}{
+		{name: "Any match", slice: []int{1, 2}},
+		{
+			name:            "None match",
+			slice:           []int{1, 3},
+			expectedMessage: "expected an element to match, but none of []int{1, 3} do",
+			expectedCalls:   1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+
+			AnyMatch(mockT, tt.slice, even)
+			n := len(mockT.ErrorfCalls)
+
+			if n != tt.expectedCalls {
+				t.Fatalf("expected %d calls to Errorf(), got %d", tt.expectedCalls, n)
+			}
+
+			if n != mockT.HelperCalls {
+				t.Errorf("expected %d calls to Helper(), got %d", n, mockT.HelperCalls)
+			}
+
+			if n > 0 && mockT.ErrorfCalls[0].message() != tt.expectedMessage {
+				t.Errorf("expected message:\n%s\ngot:\n%s", tt.expectedMessage, mockT.ErrorfCalls[0].message())
+			}
+		})
+	}
+}
+
+func TestNoneMatch(t *testing.T) {
+	even := func(n int) bool { return n%2 == 0 }
+
+	tests := []struct {
+		name            string
+		slice           []int
+		expectedMessage string
+		expectedCalls   int
+	}{
+		{name: "None match", slice: []int{1, 3}},
+		{
+			name:            "One matches",
+			slice:           []int{1, 4, 6},
+			expectedMessage: "expected no element to match, but element at index 1 does: 4",
+			expectedCalls:   1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+
+			NoneMatch(mockT, tt.slice, even)
+			n := len(mockT.ErrorfCalls)
+
+			if n != tt.expectedCalls {
+				t.Fatalf("expected %d calls to Errorf(), got %d", tt.expectedCalls, n)
+			}
+
+			if n != mockT.HelperCalls {
+				t.Errorf("expected %d calls to Helper(), got %d", n, mockT.HelperCalls)
+			}
+
+			if n > 0 && mockT.ErrorfCalls[0].message() != tt.expectedMessage {
+				t.Errorf("expected message:\n%s\ngot:\n%s", tt.expectedMessage, mockT.ErrorfCalls[0].message())
+			}
+		})
+	}
+}
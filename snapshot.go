@@ -0,0 +1,492 @@
+package assert
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// snapshotDir is the directory holding snapshot files. It is relative to the
+// working directory of the tests, which is the directory of the package under
+// test.
+var snapshotDir = "__snapshots__"
+
+// snapshotFile is the in memory form of a snapshot file.
+type snapshotFile struct {
+	path    string
+	entries map[string]string
+	// matched holds the names of the snapshots that have been compared
+	// against during this run.
+	matched map[string]bool
+}
+
+// snapshots holds the state of all snapshot assertions made during the run.
+var snapshots = struct {
+	sync.Mutex
+	// files holds every snapshot file that has been loaded, keyed by path.
+	files map[string]*snapshotFile
+	// counters holds the number of snapshots taken by each running test.
+	counters map[string]int
+	// lineShifts holds the source rewrites made by inline snapshots, keyed by
+	// file path.
+	lineShifts map[string][]lineShift
+}{
+	files:      make(map[string]*snapshotFile),
+	counters:   make(map[string]int),
+	lineShifts: make(map[string][]lineShift),
+}
+
+// MatchSnapshot asserts that a value matches the snapshot stored for it. The
+// value is serialized with the same formatting used to report DeepEqual
+// failures, with map keys sorted and pointers rendered by the values they point
+// to. Snapshots are stored in one file per test file, so the snapshots taken
+// in foo_test.go are stored in __snapshots__/foo_test.snap in the package
+// directory. They are named after the test or subtest along with a counter, so
+// a test may take several snapshots. A snapshot that does not exist fails the
+// assertion. Snapshots are written, and existing snapshots rewritten, when
+// tests are run with the -assert.update flag.
+func MatchSnapshot(t testing.TB, value any) {
+	t.Helper()
+
+	// The snapshot file is named after the file calling MatchSnapshot, which
+	// is a helper's file when MatchSnapshot is called from a test helper.
+	_, file, _, ok := runtime.Caller(1)
+	if !ok {
+		t.Fatalf("failed to determine the file taking the snapshot")
+		return
+	}
+
+	name := strings.TrimSuffix(filepath.Base(filepath.FromSlash(file)), ".go") + ".snap"
+	path, err := filepath.Abs(filepath.Join(snapshotDir, name))
+	if err != nil {
+		t.Fatalf("failed to determine the snapshot file: %v", err)
+		return
+	}
+
+	matchSnapshot(t, path, value)
+}
+
+// matchSnapshot asserts that a value matches its snapshot in the snapshot file
+// at path.
+func matchSnapshot(t testing.TB, path string, value any) {
+	name := nextSnapshotName(t)
+	got := prettyValue(value)
+
+	snapshots.Lock()
+	defer snapshots.Unlock()
+
+	sf, err := loadSnapshotFile(path)
+	if err != nil {
+		t.Helper()
+		t.Fatalf("failed to read snapshot file: %v", err)
+		return
+	}
+	sf.matched[name] = true
+
+	expected, ok := sf.entries[name]
+	if ok && expected == got {
+		return
+	}
+
+	if !updating() {
+		t.Helper()
+		if ok {
+			t.Errorf("value does not match snapshot %q in %s:\n%s", name, path, indent(unifiedDiff(expected, got), "\t"))
+		} else {
+			t.Errorf("snapshot %q does not exist in %s, run with -assert.update to write it:\n%s", name, path, indent(got, "\t"))
+		}
+		return
+	}
+
+	sf.entries[name] = got
+	if err := sf.save(); err != nil {
+		t.Helper()
+		t.Fatalf("failed to write snapshot file: %v", err)
+	}
+}
+
+// nextSnapshotName returns the name of the next snapshot taken by a test.
+func nextSnapshotName(t testing.TB) string {
+	snapshots.Lock()
+	defer snapshots.Unlock()
+
+	name := t.Name()
+	if _, ok := snapshots.counters[name]; !ok {
+		// Reset the counter once the test completes so that running a test
+		// several times, e.g. with -count, reuses the same snapshots.
+		t.Cleanup(func() {
+			snapshots.Lock()
+			defer snapshots.Unlock()
+			delete(snapshots.counters, name)
+		})
+	}
+
+	snapshots.counters[name]++
+	return fmt.Sprintf("%s %d", name, snapshots.counters[name])
+}
+
+// loadSnapshotFile returns the snapshot file at path, reading it the first
+// time it is requested. A missing file is treated as having no snapshots.
+// snapshots must be locked by the caller.
+func loadSnapshotFile(path string) (*snapshotFile, error) {
+	if sf, ok := snapshots.files[path]; ok {
+		return sf, nil
+	}
+
+	sf := &snapshotFile{path: path, entries: make(map[string]string), matched: make(map[string]bool)}
+
+	b, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	if err == nil {
+		if sf.entries, err = parseSnapshots(b); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+
+	snapshots.files[path] = sf
+	return sf, nil
+}
+
+// parseSnapshots parses the contents of a snapshot file. Each snapshot starts
+// with its name in brackets on its own line and ends with a line containing
+// only "---".
+func parseSnapshots(b []byte) (map[string]string, error) {
+	entries := make(map[string]string)
+
+	var (
+		name   string
+		body   []string
+		inside bool
+	)
+
+	s := bufio.NewScanner(bytes.NewReader(normalizeNewlines(b)))
+	s.Buffer(nil, len(b)+1)
+	for line := 1; s.Scan(); line++ {
+		l := s.Text()
+		switch {
+		case inside && l == "---":
+			entries[name] = strings.Join(body, "\n")
+			inside, body = false, nil
+		case inside:
+			body = append(body, l)
+		case strings.HasPrefix(l, "[") && strings.HasSuffix(l, "]"):
+			name, inside = l[1:len(l)-1], true
+		case l != "":
+			return nil, fmt.Errorf("line %d: unexpected content outside of a snapshot", line)
+		}
+	}
+
+	if inside {
+		return nil, fmt.Errorf("snapshot %q is not terminated", name)
+	}
+
+	return entries, s.Err()
+}
+
+// save writes the snapshot file, sorted by snapshot name. The file is removed
+// if it has no snapshots.
+func (sf *snapshotFile) save() error {
+	if len(sf.entries) == 0 {
+		err := os.Remove(sf.path)
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+
+	names := make([]string, 0, len(sf.entries))
+	for name := range sf.entries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b bytes.Buffer
+	for i, name := range names {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		fmt.Fprintf(&b, "[%s]\n%s\n---\n", name, sf.entries[name])
+	}
+
+	return writeFile(sf.path, b.Bytes())
+}
+
+// RunSnapshots runs the tests of a package with m.Run and then reports any
+// obsolete snapshots, which are snapshots in the package's snapshot files that
+// were not compared against by any test. When tests are run with the
+// -assert.update flag, obsolete snapshots are removed instead. It should be called from
+// TestMain, with its result passed to os.Exit.
+//
+// Obsolete snapshots are only detected when every test ran and passed, as
+// skipped or filtered tests do not take their snapshots.
+func RunSnapshots(m *testing.M) int {
+	code := m.Run()
+	if code != 0 || testsFiltered() {
+		return code
+	}
+
+	obsolete, err := removeObsoleteSnapshots(snapshotDir, updating())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to check for obsolete snapshots: %v\n", err)
+		return 1
+	}
+
+	if len(obsolete) > 0 {
+		if updating() {
+			fmt.Fprintf(os.Stderr, "removed %d obsolete snapshots:\n", len(obsolete))
+		} else {
+			fmt.Fprintf(os.Stderr, "found %d obsolete snapshots, run with -assert.update to remove them:\n", len(obsolete))
+		}
+		for _, o := range obsolete {
+			fmt.Fprintf(os.Stderr, "\t%s\n", o)
+		}
+	}
+
+	return code
+}
+
+// testsFiltered reports whether the test run is limited to a subset of the
+// tests with -run or -skip.
+func testsFiltered() bool {
+	for _, name := range []string{"test.run", "test.skip"} {
+		if f := flag.Lookup(name); f != nil && f.Value.String() != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// removeObsoleteSnapshots returns the snapshots in the snapshot files of dir
+// that have not been compared against. If remove is true, they are deleted
+// from their files.
+func removeObsoleteSnapshots(dir string, remove bool) ([]string, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.snap"))
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots.Lock()
+	defer snapshots.Unlock()
+
+	var obsolete []string
+	for _, path := range paths {
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return nil, err
+		}
+
+		sf, err := loadSnapshotFile(abs)
+		if err != nil {
+			return nil, err
+		}
+
+		var names []string
+		for name := range sf.entries {
+			if !sf.matched[name] {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			obsolete = append(obsolete, fmt.Sprintf("%s: %s", path, name))
+			if remove {
+				delete(sf.entries, name)
+			}
+		}
+
+		if remove && len(names) > 0 {
+			if err := sf.save(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return obsolete, nil
+}
+
+// MatchInlineSnapshot asserts that a value matches a snapshot stored in the
+// test's source code as the final argument of the call. The value is
+// serialized in the same way as MatchSnapshot, and leading and trailing
+// whitespace of the snapshot is ignored. An omitted snapshot fails the
+// assertion. When tests are run with the -assert.update flag, the source of
+// the calling file is rewritten with the current value as the snapshot.
+//
+//	assert.MatchInlineSnapshot(t, user, `
+//	User{
+//		Name: "alice",
+//	}
+//	`)
+func MatchInlineSnapshot(t testing.TB, value any, snapshot ...string) {
+	got := prettyValue(value)
+
+	var expected string
+	if len(snapshot) > 0 {
+		expected = strings.TrimSpace(string(normalizeNewlines([]byte(snapshot[0]))))
+		if expected == got {
+			return
+		}
+	}
+
+	if !updating() {
+		t.Helper()
+		if len(snapshot) > 0 {
+			t.Errorf("value does not match inline snapshot:\n%s", indent(unifiedDiff(expected, got), "\t"))
+		} else {
+			t.Errorf("inline snapshot is missing, run with -assert.update to write it:\n%s", indent(got, "\t"))
+		}
+		return
+	}
+
+	// The snapshot is an argument of this very call, so the caller is the
+	// file to rewrite even when it is a test helper.
+	_, file, line, ok := runtime.Caller(1)
+	if !ok {
+		t.Helper()
+		t.Fatalf("failed to determine the file taking the snapshot")
+		return
+	}
+
+	if err := rewriteInlineSnapshot(sourceFile(file), line, got); err != nil {
+		t.Helper()
+		t.Fatalf("failed to write inline snapshot: %v", err)
+	}
+}
+
+// sourceFile returns the path of a source file reported by runtime.Caller.
+// Paths are not absolute when tests are built with -trimpath, in which case
+// the file is looked up in the working directory of the tests.
+func sourceFile(file string) string {
+	if filepath.IsAbs(file) {
+		return file
+	}
+	return filepath.Base(filepath.FromSlash(file))
+}
+
+// lineShift records a source rewrite that changed the number of lines in a
+// file.
+type lineShift struct {
+	// line is the line of the rewritten call in the original source.
+	line int
+	// delta is the number of lines added by the rewrite.
+	delta int
+}
+
+// rewriteInlineSnapshot replaces the snapshot of the MatchInlineSnapshot call
+// at the provided line of a file. The line is a line of the file as it was
+// compiled, so earlier rewrites of the same file are accounted for.
+func rewriteInlineSnapshot(file string, line int, snapshot string) error {
+	snapshots.Lock()
+	defer snapshots.Unlock()
+
+	current := line
+	for _, s := range snapshots.lineShifts[file] {
+		if s.line < line {
+			current += s.delta
+		}
+	}
+
+	src, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, file, src, parser.ParseComments)
+	if err != nil {
+		return err
+	}
+
+	call := findInlineSnapshotCall(fset, f, current)
+	if call == nil {
+		return fmt.Errorf("%s:%d: no call to MatchInlineSnapshot found", file, line)
+	}
+
+	literal := inlineSnapshotLiteral(snapshot)
+
+	var start, end int
+	if len(call.Args) > 2 {
+		start = fset.Position(call.Args[2].Pos()).Offset
+		end = fset.Position(call.Args[len(call.Args)-1].End()).Offset
+	} else {
+		start = fset.Position(call.Args[len(call.Args)-1].End()).Offset
+		end = start
+		literal = ", " + literal
+	}
+
+	// Only the snapshot argument is replaced, leaving the rest of the file
+	// untouched.
+	var b bytes.Buffer
+	b.Write(src[:start])
+	b.WriteString(literal)
+	b.Write(src[end:])
+	out := b.Bytes()
+
+	if err := os.WriteFile(file, out, 0o644); err != nil {
+		return err
+	}
+
+	delta := bytes.Count(out, []byte("\n")) - bytes.Count(src, []byte("\n"))
+	if delta != 0 {
+		snapshots.lineShifts[file] = append(snapshots.lineShifts[file], lineShift{line: line, delta: delta})
+	}
+	return nil
+}
+
+// findInlineSnapshotCall returns the innermost call to MatchInlineSnapshot
+// spanning the provided line.
+func findInlineSnapshotCall(fset *token.FileSet, f *ast.File, line int) *ast.CallExpr {
+	var found *ast.CallExpr
+	ast.Inspect(f, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || len(call.Args) < 2 {
+			return true
+		}
+
+		var name string
+		switch fn := call.Fun.(type) {
+		case *ast.Ident:
+			name = fn.Name
+		case *ast.SelectorExpr:
+			name = fn.Sel.Name
+		}
+
+		first, last := fset.Position(call.Pos()).Line, fset.Position(call.End()).Line
+		if name == "MatchInlineSnapshot" && first <= line && line <= last {
+			found = call
+		}
+		return true
+	})
+	return found
+}
+
+// inlineSnapshotLiteral returns the Go string literal holding a snapshot.
+// Multiline snapshots are written as raw strings starting and ending with a
+// newline so that they read naturally in the source.
+func inlineSnapshotLiteral(snapshot string) string {
+	if strings.Contains(snapshot, "`") {
+		return strconv.Quote(snapshot)
+	}
+
+	if strings.Contains(snapshot, "\n") {
+		return "`\n" + snapshot + "\n`"
+	}
+	return "`" + snapshot + "`"
+}
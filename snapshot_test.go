@@ -0,0 +1,253 @@
+package assert
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// resetSnapshots clears the snapshot state for the duration of a test.
+func resetSnapshots(t *testing.T) {
+	snapshots.Lock()
+	defer snapshots.Unlock()
+
+	files, counters, lineShifts := snapshots.files, snapshots.counters, snapshots.lineShifts
+	t.Cleanup(func() {
+		snapshots.Lock()
+		defer snapshots.Unlock()
+		snapshots.files, snapshots.counters, snapshots.lineShifts = files, counters, lineShifts
+	})
+
+	snapshots.files = make(map[string]*snapshotFile)
+	snapshots.counters = make(map[string]int)
+	snapshots.lineShifts = make(map[string][]lineShift)
+}
+
+// useSnapshotDir points snapshot files at a temporary directory for the
+// duration of a test.
+func useSnapshotDir(t *testing.T) string {
+	dir := t.TempDir()
+	prev := snapshotDir
+	snapshotDir = dir
+	t.Cleanup(func() { snapshotDir = prev })
+	return dir
+}
+
+type snapshotUser struct {
+	Name  string
+	Roles map[string]bool
+	Next  *snapshotUser
+}
+
+func TestMatchSnapshot(t *testing.T) {
+	resetSnapshots(t)
+	dir := useSnapshotDir(t)
+	user := snapshotUser{Name: "alice", Roles: map[string]bool{"admin": true}}
+
+	// Missing snapshots fail unless they are being updated.
+	mockT := &namedMockTB{mockTB: newMockTB(), name: "TestFoo/bar"}
+	MatchSnapshot(mockT, user)
+	mockT.RunCleanups()
+
+	if len(mockT.ErrorfCalls) != 1 {
+		t.Fatalf("expected 1 call to Errorf(), got %d", len(mockT.ErrorfCalls))
+	}
+
+	if mockT.HelperCalls != 2 {
+		t.Errorf("expected 2 calls to Helper(), got %d", mockT.HelperCalls)
+	}
+
+	// Snapshots are stored in a file named after the calling test file.
+	path := filepath.Join(dir, "snapshot_test.snap")
+	if msg := mockT.ErrorfCalls[0].message(); !strings.HasPrefix(msg, `snapshot "TestFoo/bar 1" does not exist in `+path+", run with -assert.update to write it:\n") {
+		t.Errorf("unexpected message:\n%s", msg)
+	}
+
+	if _, err := os.Stat(path); err == nil {
+		t.Errorf("expected missing snapshot not to be written")
+	}
+
+	setUpdate(t, true)
+	mockT.Reset()
+	MatchSnapshot(mockT, user)
+	mockT.RunCleanups()
+	setUpdate(t, false)
+
+	b, err := os.ReadFile(path)
+	NoError(Fatal(t), err)
+	Equal(t, string(b), "[TestFoo/bar 1]\nassert.snapshotUser{\n\tName: \"alice\",\n\tRoles: map[string]bool{\n\t\t\"admin\": true,\n\t},\n\tNext: (*assert.snapshotUser)(nil),\n}\n---\n")
+
+	mockT.Reset()
+	MatchSnapshot(mockT, user)
+	mockT.RunCleanups()
+
+	if n := len(mockT.ErrorfCalls) + len(mockT.FatalfCalls); n != 0 {
+		t.Errorf("expected no failures when matching the snapshot, got %d", n)
+	}
+
+	if mockT.HelperCalls != 1 {
+		t.Errorf("expected 1 call to Helper(), got %d", mockT.HelperCalls)
+	}
+}
+
+func TestMatchSnapshotFile(t *testing.T) {
+	resetSnapshots(t)
+	path := filepath.Join(t.TempDir(), snapshotDir, "foo_test.snap")
+
+	// The first run writes the snapshots.
+	setUpdate(t, true)
+	mockT := &namedMockTB{mockTB: newMockTB(), name: "TestFoo"}
+	matchSnapshot(mockT, path, map[string]int{"b": 2, "a": 1})
+	matchSnapshot(mockT, path, "second")
+	mockT.RunCleanups()
+	setUpdate(t, false)
+
+	if n := len(mockT.ErrorfCalls) + len(mockT.FatalfCalls); n != 0 {
+		t.Fatalf("expected no failures when writing snapshots, got %d", n)
+	}
+
+	b, err := os.ReadFile(path)
+	NoError(Fatal(t), err)
+	Equal(t, string(b), "[TestFoo 1]\nmap[string]int{\n\t\"a\": 1,\n\t\"b\": 2,\n}\n---\n\n[TestFoo 2]\n\"second\"\n---\n")
+
+	// Later runs compare against the stored snapshots.
+	resetSnapshots(t)
+	mockT.Reset()
+	matchSnapshot(mockT, path, map[string]int{"a": 1, "b": 2})
+	matchSnapshot(mockT, path, "changed")
+	mockT.RunCleanups()
+
+	if len(mockT.ErrorfCalls) != 1 {
+		t.Fatalf("expected 1 call to Errorf(), got %d", len(mockT.ErrorfCalls))
+	}
+
+	msg := mockT.ErrorfCalls[0].message()
+	if !strings.HasPrefix(msg, `value does not match snapshot "TestFoo 2"`) || !strings.Contains(msg, "\t-\"second\"\n\t+\"changed\"") {
+		t.Errorf("unexpected message:\n%s", msg)
+	}
+
+	if mockT.HelperCalls != 1 {
+		t.Errorf("expected 1 call to Helper(), got %d", mockT.HelperCalls)
+	}
+}
+
+func TestMatchSnapshotUpdate(t *testing.T) {
+	resetSnapshots(t)
+	setUpdate(t, true)
+	path := filepath.Join(t.TempDir(), "foo_test.snap")
+	NoError(Fatal(t), writeFile(path, []byte("[TestFoo 1]\n\"old\"\n---\n")))
+
+	mockT := &namedMockTB{mockTB: newMockTB(), name: "TestFoo"}
+	matchSnapshot(mockT, path, "new")
+
+	if n := len(mockT.ErrorfCalls) + len(mockT.FatalfCalls); n != 0 {
+		t.Fatalf("expected no failures, got %d", n)
+	}
+
+	b, err := os.ReadFile(path)
+	NoError(Fatal(t), err)
+	Equal(t, string(b), "[TestFoo 1]\n\"new\"\n---\n")
+}
+
+func TestRemoveObsoleteSnapshots(t *testing.T) {
+	resetSnapshots(t)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "foo_test.snap")
+	NoError(Fatal(t), writeFile(path, []byte("[TestFoo 1]\n1\n---\n\n[TestBar 1]\n2\n---\n")))
+
+	mockT := &namedMockTB{mockTB: newMockTB(), name: "TestFoo"}
+	matchSnapshot(mockT, path, 1)
+
+	obsolete, err := removeObsoleteSnapshots(dir, false)
+	NoError(Fatal(t), err)
+	DeepEqual(t, obsolete, []string{path + ": TestBar 1"})
+
+	_, err = removeObsoleteSnapshots(dir, true)
+	NoError(Fatal(t), err)
+
+	b, err := os.ReadFile(path)
+	NoError(Fatal(t), err)
+	Equal(t, string(b), "[TestFoo 1]\n1\n---\n")
+}
+
+func TestParseSnapshotsErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "Unterminated", input: "[TestFoo 1]\n1\n"},
+		{name: "Stray content", input: "1\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := parseSnapshots([]byte(tt.input)); err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestMatchInlineSnapshot(t *testing.T) {
+	mockT := newMockTB()
+
+	MatchInlineSnapshot(mockT, []int{1, 2}, `
+[]int{
+	1,
+	2,
+}
+`)
+	MatchInlineSnapshot(mockT, "x", `"x"`)
+	MatchInlineSnapshot(mockT, "x", `"y"`)
+	MatchInlineSnapshot(mockT, "x")
+
+	if len(mockT.ErrorfCalls) != 2 {
+		t.Fatalf("expected 2 calls to Errorf(), got %d", len(mockT.ErrorfCalls))
+	}
+
+	if mockT.HelperCalls != 2 {
+		t.Errorf("expected 2 calls to Helper(), got %d", mockT.HelperCalls)
+	}
+
+	expected := "inline snapshot is missing, run with -assert.update to write it:\n\t\"x\""
+	if msg := mockT.ErrorfCalls[1].message(); msg != expected {
+		t.Errorf("expected message %q, got %q", expected, msg)
+	}
+}
+
+func TestSourceFile(t *testing.T) {
+	abs := filepath.Join(t.TempDir(), "foo_test.go")
+	Equal(t, sourceFile(abs), abs)
+	Equal(t, sourceFile("github.com/mattmeyers/assert/foo_test.go"), "foo_test.go")
+}
+
+func TestRewriteInlineSnapshot(t *testing.T) {
+	resetSnapshots(t)
+	file := filepath.Join(t.TempDir(), "foo_test.go")
+	src := `package foo
+
+func TestFoo(t *testing.T) {
+	assert.MatchInlineSnapshot(t, a)
+	assert.MatchInlineSnapshot(t, b, "old")
+}
+
+var unformatted = map[string]int{"a":1,
+	"bb":  2}
+`
+	NoError(Fatal(t), os.WriteFile(file, []byte(src), 0o644))
+
+	// Lines are those of the original source, so the second rewrite must
+	// account for the lines added by the first.
+	NoError(t, rewriteInlineSnapshot(file, 4, "[]int{\n\t1,\n}"))
+	NoError(t, rewriteInlineSnapshot(file, 5, `"new"`))
+
+	b, err := os.ReadFile(file)
+	NoError(Fatal(t), err)
+
+	expected := "package foo\n\nfunc TestFoo(t *testing.T) {\n" +
+		"\tassert.MatchInlineSnapshot(t, a, `\n[]int{\n\t1,\n}\n`)\n" +
+		"\tassert.MatchInlineSnapshot(t, b, `\"new\"`)\n" +
+		"}\n\n" +
+		"var unformatted = map[string]int{\"a\":1,\n\t\"bb\":  2}\n"
+	Equal(t, string(b), expected)
+}
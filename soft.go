@@ -0,0 +1,155 @@
+package assert
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// SoftTB is a wrapper around a testing.TB that records failed assertions
+// instead of reporting them immediately. Every recorded failure is reported in
+// a single numbered summary once the test completes, or earlier by calling
+// Done. Fatal failures are recorded as well, but stop the test after reporting
+// the summary.
+type SoftTB struct {
+	testing.TB
+
+	mu       sync.Mutex
+	failures []softFailure
+	helpers  map[string]bool
+}
+
+// softFailure is a single failure recorded by a SoftTB.
+type softFailure struct {
+	file string
+	line int
+	msg  string
+}
+
+// Soft builds a SoftTB for grouping the failures of many assertions into a
+// single summary. The summary is reported when the test and all of its subtests
+// complete.
+func Soft(t testing.TB) *SoftTB {
+	s := &SoftTB{TB: t, helpers: make(map[string]bool)}
+	t.Cleanup(func() {
+		s.report(s.TB.Errorf)
+	})
+	return s
+}
+
+// Done reports the summary of all failures recorded so far and stops the test
+// if there were any. Failures recorded after calling Done are reported when the
+// test completes.
+func (s *SoftTB) Done() {
+	s.TB.Helper()
+	s.report(s.TB.Fatalf)
+}
+
+// Helper marks the calling function as a helper so that it is skipped when
+// determining the location of a failure.
+func (s *SoftTB) Helper() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if name := callerName(1); name != "" {
+		s.helpers[name] = true
+	}
+}
+
+func (s *SoftTB) Error(args ...any) {
+	s.record(sprintln(args...))
+}
+
+func (s *SoftTB) Errorf(format string, args ...any) {
+	s.record(fmt.Sprintf(format, args...))
+}
+
+func (s *SoftTB) Fatal(args ...any) {
+	s.TB.Helper()
+	s.record(sprintln(args...))
+	s.Done()
+}
+
+func (s *SoftTB) Fatalf(format string, args ...any) {
+	s.TB.Helper()
+	s.record(fmt.Sprintf(format, args...))
+	s.Done()
+}
+
+// Failed reports whether the test has failed or any failures have been
+// recorded but not yet reported.
+func (s *SoftTB) Failed() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.failures) > 0 || s.TB.Failed()
+}
+
+// record stores a failure along with the location of the first caller that
+// has not been marked as a helper.
+func (s *SoftTB) record(msg string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f := softFailure{file: "???", line: 1, msg: msg}
+
+	pcs := make([]uintptr, 50)
+	// Skip runtime.Callers, record, and the SoftTB method that called record.
+	n := runtime.Callers(3, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if !s.helpers[frame.Function] {
+			f.file, f.line = filepath.Base(frame.File), frame.Line
+			break
+		}
+		if !more {
+			break
+		}
+	}
+
+	s.failures = append(s.failures, f)
+}
+
+// report writes the summary of all recorded failures with the provided
+// function and clears them.
+func (s *SoftTB) report(fail func(format string, args ...any)) {
+	s.mu.Lock()
+	failures := s.failures
+	s.failures = nil
+	s.mu.Unlock()
+
+	if len(failures) == 0 {
+		return
+	}
+
+	var b strings.Builder
+	if len(failures) == 1 {
+		b.WriteString("1 assertion failed:")
+	} else {
+		fmt.Fprintf(&b, "%d assertions failed:", len(failures))
+	}
+
+	for i, f := range failures {
+		prefix := fmt.Sprintf("%d. ", i+1)
+		msg := strings.ReplaceAll(f.msg, "\n", "\n"+strings.Repeat(" ", len(prefix)))
+		fmt.Fprintf(&b, "\n%s%s:%d: %s", prefix, f.file, f.line, msg)
+	}
+
+	fail("%s", b.String())
+}
+
+// callerName returns the fully qualified name of the function skip frames
+// above the caller of callerName.
+func callerName(skip int) string {
+	pcs := make([]uintptr, 1)
+	if runtime.Callers(skip+2, pcs) == 0 {
+		return ""
+	}
+
+	frame, _ := runtime.CallersFrames(pcs).Next()
+	return frame.Function
+}
@@ -0,0 +1,110 @@
+package assert
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestSoftReportsSummaryOnCleanup(t *testing.T) {
+	mockT := newMockTB()
+	s := Soft(mockT)
+
+	_, _, line, _ := runtime.Caller(0)
+	Equal(s, 1, 2)
+	NoError(s, errors.New("uh oh"))
+	Equal(s, 1, 1)
+
+	if len(mockT.ErrorfCalls) != 0 {
+		t.Fatalf("expected 0 calls to Errorf() before cleanup, got %d", len(mockT.ErrorfCalls))
+	}
+
+	if !s.Failed() {
+		t.Error("expected soft TB to report failure")
+	}
+
+	mockT.RunCleanups()
+
+	if len(mockT.ErrorfCalls) != 1 {
+		t.Fatalf("expected 1 call to Errorf(), got %d", len(mockT.ErrorfCalls))
+	}
+
+	expected := fmt.Sprintf(
+		"2 assertions failed:\n1. soft_test.go:%d: expected \"2\", got \"1\"\n2. soft_test.go:%d: expected no error, got \"uh oh\"",
+		line+1, line+2,
+	)
+	if msg := mockT.ErrorfCalls[0].message(); msg != expected {
+		t.Errorf("expected message:\n%s\ngot:\n%s", expected, msg)
+	}
+}
+
+func TestSoftNoFailures(t *testing.T) {
+	mockT := newMockTB()
+	s := Soft(mockT)
+
+	Equal(s, 1, 1)
+	s.Done()
+	mockT.RunCleanups()
+
+	if n := len(mockT.ErrorfCalls) + len(mockT.FatalfCalls); n != 0 {
+		t.Errorf("expected no failures to be reported, got %d", n)
+	}
+}
+
+func TestSoftDone(t *testing.T) {
+	mockT := newMockTB()
+	s := Soft(mockT)
+
+	Equal(s, 1, 2)
+	s.Done()
+
+	if len(mockT.FatalfCalls) != 1 {
+		t.Fatalf("expected 1 call to Fatalf(), got %d", len(mockT.FatalfCalls))
+	}
+
+	Equal(s, "a", "b")
+	mockT.RunCleanups()
+
+	if len(mockT.ErrorfCalls) != 1 {
+		t.Fatalf("expected 1 call to Errorf(), got %d", len(mockT.ErrorfCalls))
+	}
+
+	if msg := mockT.ErrorfCalls[0].message(); !strings.HasPrefix(msg, "1 assertion failed:\n1. soft_test.go:") {
+		t.Errorf("unexpected message:\n%s", msg)
+	}
+}
+
+func TestSoftFatal(t *testing.T) {
+	mockT := newMockTB()
+	s := Soft(mockT)
+
+	Equal(s, 1, 2)
+	NoError(Fatal(s), errors.New("uh oh"))
+
+	if len(mockT.FatalfCalls) != 1 {
+		t.Fatalf("expected 1 call to Fatalf(), got %d", len(mockT.FatalfCalls))
+	}
+
+	if msg := mockT.FatalfCalls[0].message(); !strings.HasPrefix(msg, "2 assertions failed:") {
+		t.Errorf("unexpected message:\n%s", msg)
+	}
+}
+
+func TestSoftWithMessage(t *testing.T) {
+	mockT := newMockTB()
+	s := Soft(mockT)
+
+	Equal(With(s, Msg("first line\nsecond line")), 1, 2)
+	mockT.RunCleanups()
+
+	if len(mockT.ErrorfCalls) != 1 {
+		t.Fatalf("expected 1 call to Errorf(), got %d", len(mockT.ErrorfCalls))
+	}
+
+	msg := mockT.ErrorfCalls[0].message()
+	if !strings.Contains(msg, "1. soft_test.go:") || !strings.HasSuffix(msg, "\n   first line\n   second line") {
+		t.Errorf("unexpected message:\n%s", msg)
+	}
+}
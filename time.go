@@ -0,0 +1,133 @@
+package assert
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mattmeyers/assert/clock"
+)
+
+// TimeEqual asserts that two times represent the same instant, using
+// time.Time.Equal. Unlike Equal, times with differing locations or monotonic
+// clock readings are equal if they represent the same instant.
+func TimeEqual(t testing.TB, got, expected time.Time) {
+	if !got.Equal(expected) {
+		t.Helper()
+		t.Errorf("expected time %s, got %s, delta %s", formatTime(expected), formatTime(got), formatDelta(got.Sub(expected)))
+	}
+}
+
+// WithinDuration asserts that a time is at most tolerance before or after an
+// expected time.
+func WithinDuration(t testing.TB, got, expected time.Time, tolerance time.Duration) {
+	if tolerance < 0 {
+		t.Helper()
+		t.Fatalf("invalid tolerance %s", tolerance)
+		return
+	}
+
+	if d := got.Sub(expected); d < -tolerance || d > tolerance {
+		t.Helper()
+		t.Errorf("expected %s to be within %s of %s, delta %s", formatTime(got), tolerance, formatTime(expected), formatDelta(d))
+	}
+}
+
+// WithinDurationOfNow asserts that a time is at most tolerance before or after
+// the current time of a clock. With a clock.FakeClock, the time is compared to
+// the fake clock's notion of now.
+func WithinDurationOfNow(t testing.TB, got time.Time, c clock.Clock, tolerance time.Duration) {
+	if tolerance < 0 {
+		t.Helper()
+		t.Fatalf("invalid tolerance %s", tolerance)
+		return
+	}
+
+	now := c.Now()
+	if d := got.Sub(now); d < -tolerance || d > tolerance {
+		t.Helper()
+		t.Errorf("expected %s to be within %s of now (%s), delta %s", formatTime(got), tolerance, formatTime(now), formatDelta(d))
+	}
+}
+
+// Before asserts that a time is strictly before another time.
+func Before(t testing.TB, got, other time.Time) {
+	if !got.Before(other) {
+		t.Helper()
+		t.Errorf("expected %s to be before %s, delta %s", formatTime(got), formatTime(other), formatDelta(got.Sub(other)))
+	}
+}
+
+// After asserts that a time is strictly after another time.
+func After(t testing.TB, got, other time.Time) {
+	if !got.After(other) {
+		t.Helper()
+		t.Errorf("expected %s to be after %s, delta %s", formatTime(got), formatTime(other), formatDelta(got.Sub(other)))
+	}
+}
+
+// SameDay asserts that a time falls on the same calendar day as an expected
+// time, in the location of the expected time.
+func SameDay(t testing.TB, got, expected time.Time) {
+	g := got.In(expected.Location())
+	gy, gm, gd := g.Date()
+	ey, em, ed := expected.Date()
+	if gy != ey || gm != em || gd != ed {
+		t.Helper()
+		t.Errorf("expected %s to be on %s in %s, got %s", formatTime(got), expected.Format("2006-01-02"), expected.Location(), g.Format("2006-01-02"))
+	}
+}
+
+// TruncatedEqual asserts that two times are equal after being truncated to a
+// multiple of unit, as by time.Time.Truncate. Truncation operates on absolute
+// time, so units of a day or longer truncate to boundaries in UTC.
+func TruncatedEqual(t testing.TB, got, expected time.Time, unit time.Duration) {
+	if unit <= 0 {
+		t.Helper()
+		t.Fatalf("invalid unit %s", unit)
+		return
+	}
+
+	g, e := got.Truncate(unit), expected.Truncate(unit)
+	if !g.Equal(e) {
+		t.Helper()
+		t.Errorf("expected time %s, got %s when truncated to %s, delta %s", formatTime(e), formatTime(g), unit, formatDelta(g.Sub(e)))
+	}
+}
+
+// DurationInRange asserts that a duration is greater than or equal to low and
+// less than or equal to high.
+func DurationInRange(t testing.TB, got, low, high time.Duration) {
+	if low > high {
+		t.Helper()
+		t.Fatalf("invalid range %s", Inclusive.interval(low.String(), high.String()))
+		return
+	}
+
+	if reason := rangeReason(got, low, high, Inclusive); reason != "" {
+		t.Helper()
+		t.Errorf("expected %s to be in range %s, but %s", got, Inclusive.interval(low.String(), high.String()), reason)
+	}
+}
+
+// TimeInLocation asserts that a time is in the expected location. Locations
+// are compared by name, so separately loaded copies of a location are equal.
+func TimeInLocation(t testing.TB, got time.Time, expected *time.Location) {
+	if got.Location().String() != expected.String() {
+		t.Helper()
+		t.Errorf("expected %s to be in location %s, got %s", formatTime(got), expected, got.Location())
+	}
+}
+
+// formatTime formats a time in RFC 3339 with nanoseconds, without its
+// monotonic clock reading.
+func formatTime(t time.Time) string {
+	return t.Format(time.RFC3339Nano)
+}
+
+// formatDelta formats a duration with an explicit sign.
+func formatDelta(d time.Duration) string {
+	if d >= 0 {
+		return "+" + d.String()
+	}
+	return d.String()
+}
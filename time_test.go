@@ -0,0 +1,400 @@
+package assert
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mattmeyers/assert/clock"
+)
+
+var (
+	testTime = time.Date(2024, 3, 9, 23, 30, 0, 500, time.UTC)
+	newYork  = time.FixedZone("EST", -5*60*60)
+)
+
+func TestTimeEqual(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name            string
+		got             time.Time
+		expected        time.Time
+		expectedMessage string
+		expectedCalls   int
+	}{
+		{name: "Same instant in another location", got: testTime.In(newYork), expected: testTime},
+		{name: "Monotonic clock reading", got: now, expected: now.Round(0)},
+		{
+			name:            "Different instants",
+			got:             testTime.Add(-1500 * time.Millisecond),
+			expected:        testTime,
+			expectedMessage: "expected time 2024-03-09T23:30:00.0000005Z, got 2024-03-09T23:29:58.5000005Z, delta -1.5s",
+			expectedCalls:   1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+
+			TimeEqual(mockT, tt.got, tt.expected)
+			n := len(mockT.ErrorfCalls)
+
+			if n != tt.expectedCalls {
+				t.Fatalf("expected %d calls to Errorf(), got %d", tt.expectedCalls, n)
+			}
+
+			if n != mockT.HelperCalls {
+				t.Errorf("expected %d calls to Helper(), got %d", n, mockT.HelperCalls)
+			}
+
+			if n > 0 && mockT.ErrorfCalls[0].message() != tt.expectedMessage {
+				t.Errorf("expected message:\n%s\ngot:\n%s", tt.expectedMessage, mockT.ErrorfCalls[0].message())
+			}
+		})
+	}
+}
+
+func TestWithinDuration(t *testing.T) {
+	tests := []struct {
+		name               string
+		got                time.Time
+		tolerance          time.Duration
+		expectedMessage    string
+		expectedErrorCalls int
+		expectedFatalCalls int
+	}{
+		{name: "Within tolerance", got: testTime.Add(-time.Second), tolerance: time.Second},
+		{
+			name:               "Outside tolerance",
+			got:                testTime.Add(2 * time.Minute),
+			tolerance:          time.Minute,
+			expectedMessage:    "expected 2024-03-09T23:32:00.0000005Z to be within 1m0s of 2024-03-09T23:30:00.0000005Z, delta +2m0s",
+			expectedErrorCalls: 1,
+		},
+		{
+			name:               "Negative tolerance",
+			got:                testTime,
+			tolerance:          -time.Second,
+			expectedMessage:    "invalid tolerance -1s",
+			expectedFatalCalls: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+
+			WithinDuration(mockT, tt.got, testTime, tt.tolerance)
+			n := len(mockT.ErrorfCalls)
+			m := len(mockT.FatalfCalls)
+
+			if n != tt.expectedErrorCalls {
+				t.Fatalf("expected %d calls to Errorf(), got %d", tt.expectedErrorCalls, n)
+			}
+
+			if m != tt.expectedFatalCalls {
+				t.Fatalf("expected %d calls to Fatalf(), got %d", tt.expectedFatalCalls, m)
+			}
+
+			if mockT.HelperCalls != n+m {
+				t.Errorf("expected %d calls to Helper(), got %d", n+m, mockT.HelperCalls)
+			}
+
+			if n > 0 && mockT.ErrorfCalls[0].message() != tt.expectedMessage {
+				t.Errorf("expected message:\n%s\ngot:\n%s", tt.expectedMessage, mockT.ErrorfCalls[0].message())
+			}
+
+			if m > 0 && mockT.FatalfCalls[0].message() != tt.expectedMessage {
+				t.Errorf("expected message:\n%s\ngot:\n%s", tt.expectedMessage, mockT.FatalfCalls[0].message())
+			}
+		})
+	}
+}
+
+func TestWithinDurationOfNow(t *testing.T) {
+	c := clock.NewFake(testTime)
+	c.Advance(time.Hour)
+
+	tests := []struct {
+		name            string
+		got             time.Time
+		clock           clock.Clock
+		tolerance       time.Duration
+		expectedMessage string
+		expectedCalls   int
+	}{
+		{name: "Within tolerance of fake time", got: testTime.Add(time.Hour - time.Second), clock: c, tolerance: time.Second},
+		{
+			name:            "Outside tolerance of fake time",
+			got:             testTime,
+			clock:           c,
+			tolerance:       time.Minute,
+			expectedMessage: "expected 2024-03-09T23:30:00.0000005Z to be within 1m0s of now (2024-03-10T00:30:00.0000005Z), delta -1h0m0s",
+			expectedCalls:   1,
+		},
+		{name: "Real clock", got: time.Now(), clock: clock.Real(), tolerance: time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+
+			WithinDurationOfNow(mockT, tt.got, tt.clock, tt.tolerance)
+			n := len(mockT.ErrorfCalls)
+
+			if n != tt.expectedCalls {
+				t.Fatalf("expected %d calls to Errorf(), got %d", tt.expectedCalls, n)
+			}
+
+			if n != mockT.HelperCalls {
+				t.Errorf("expected %d calls to Helper(), got %d", n, mockT.HelperCalls)
+			}
+
+			if n > 0 && mockT.ErrorfCalls[0].message() != tt.expectedMessage {
+				t.Errorf("expected message:\n%s\ngot:\n%s", tt.expectedMessage, mockT.ErrorfCalls[0].message())
+			}
+		})
+	}
+}
+
+func TestBeforeAndAfter(t *testing.T) {
+	tests := []struct {
+		name            string
+		assert          func(t testing.TB, got, other time.Time)
+		got             time.Time
+		other           time.Time
+		expectedMessage string
+		expectedCalls   int
+	}{
+		{name: "Before", assert: Before, got: testTime, other: testTime.Add(time.Nanosecond)},
+		{
+			name:            "Not before equal time",
+			assert:          Before,
+			got:             testTime,
+			other:           testTime,
+			expectedMessage: "expected 2024-03-09T23:30:00.0000005Z to be before 2024-03-09T23:30:00.0000005Z, delta +0s",
+			expectedCalls:   1,
+		},
+		{name: "After", assert: After, got: testTime, other: testTime.Add(-time.Hour)},
+		{
+			name:            "Not after",
+			assert:          After,
+			got:             testTime,
+			other:           testTime.Add(time.Hour),
+			expectedMessage: "expected 2024-03-09T23:30:00.0000005Z to be after 2024-03-10T00:30:00.0000005Z, delta -1h0m0s",
+			expectedCalls:   1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+
+			tt.assert(mockT, tt.got, tt.other)
+			n := len(mockT.ErrorfCalls)
+
+			if n != tt.expectedCalls {
+				t.Fatalf("expected %d calls to Errorf(), got %d", tt.expectedCalls, n)
+			}
+
+			if n != mockT.HelperCalls {
+				t.Errorf("expected %d calls to Helper(), got %d", n, mockT.HelperCalls)
+			}
+
+			if n > 0 && mockT.ErrorfCalls[0].message() != tt.expectedMessage {
+				t.Errorf("expected message:\n%s\ngot:\n%s", tt.expectedMessage, mockT.ErrorfCalls[0].message())
+			}
+		})
+	}
+}
+
+func TestSameDay(t *testing.T) {
+	tests := []struct {
+		name            string
+		got             time.Time
+		expected        time.Time
+		expectedMessage string
+		expectedCalls   int
+	}{
+		{name: "Same day", got: testTime, expected: time.Date(2024, 3, 9, 0, 0, 0, 0, time.UTC)},
+		{name: "Same day in location of expected time", got: testTime.Add(2 * time.Hour), expected: time.Date(2024, 3, 9, 12, 0, 0, 0, newYork)},
+		{
+			name:            "Different day",
+			got:             testTime.Add(time.Hour),
+			expected:        testTime,
+			expectedMessage: "expected 2024-03-10T00:30:00.0000005Z to be on 2024-03-09 in UTC, got 2024-03-10",
+			expectedCalls:   1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+
+			SameDay(mockT, tt.got, tt.expected)
+			n := len(mockT.ErrorfCalls)
+
+			if n != tt.expectedCalls {
+				t.Fatalf("expected %d calls to Errorf(), got %d", tt.expectedCalls, n)
+			}
+
+			if n != mockT.HelperCalls {
+				t.Errorf("expected %d calls to Helper(), got %d", n, mockT.HelperCalls)
+			}
+
+			if n > 0 && mockT.ErrorfCalls[0].message() != tt.expectedMessage {
+				t.Errorf("expected message:\n%s\ngot:\n%s", tt.expectedMessage, mockT.ErrorfCalls[0].message())
+			}
+		})
+	}
+}
+
+func TestTruncatedEqual(t *testing.T) {
+	tests := []struct {
+		name               string
+		got                time.Time
+		unit               time.Duration
+		expectedMessage    string
+		expectedErrorCalls int
+		expectedFatalCalls int
+	}{
+		{name: "Equal when truncated", got: testTime.Add(20 * time.Minute), unit: time.Hour},
+		{
+			name:               "Not equal when truncated",
+			got:                testTime.Add(time.Second),
+			unit:               time.Second,
+			expectedMessage:    "expected time 2024-03-09T23:30:00Z, got 2024-03-09T23:30:01Z when truncated to 1s, delta +1s",
+			expectedErrorCalls: 1,
+		},
+		{
+			name:               "Invalid unit",
+			got:                testTime,
+			unit:               0,
+			expectedMessage:    "invalid unit 0s",
+			expectedFatalCalls: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+
+			TruncatedEqual(mockT, tt.got, testTime, tt.unit)
+			n := len(mockT.ErrorfCalls)
+			m := len(mockT.FatalfCalls)
+
+			if n != tt.expectedErrorCalls {
+				t.Fatalf("expected %d calls to Errorf(), got %d", tt.expectedErrorCalls, n)
+			}
+
+			if m != tt.expectedFatalCalls {
+				t.Fatalf("expected %d calls to Fatalf(), got %d", tt.expectedFatalCalls, m)
+			}
+
+			if mockT.HelperCalls != n+m {
+				t.Errorf("expected %d calls to Helper(), got %d", n+m, mockT.HelperCalls)
+			}
+
+			if n > 0 && mockT.ErrorfCalls[0].message() != tt.expectedMessage {
+				t.Errorf("expected message:\n%s\ngot:\n%s", tt.expectedMessage, mockT.ErrorfCalls[0].message())
+			}
+
+			if m > 0 && mockT.FatalfCalls[0].message() != tt.expectedMessage {
+				t.Errorf("expected message:\n%s\ngot:\n%s", tt.expectedMessage, mockT.FatalfCalls[0].message())
+			}
+		})
+	}
+}
+
+func TestDurationInRange(t *testing.T) {
+	tests := []struct {
+		name               string
+		got                time.Duration
+		low                time.Duration
+		high               time.Duration
+		expectedMessage    string
+		expectedErrorCalls int
+		expectedFatalCalls int
+	}{
+		{name: "Within range", got: 90 * time.Second, low: time.Minute, high: 2 * time.Minute},
+		{
+			name:               "Above range",
+			got:                3 * time.Minute,
+			low:                time.Minute,
+			high:               2 * time.Minute,
+			expectedMessage:    "expected 3m0s to be in range [1m0s, 2m0s], but it is greater than the upper bound",
+			expectedErrorCalls: 1,
+		},
+		{
+			name:               "Invalid range",
+			got:                time.Second,
+			low:                time.Minute,
+			high:               time.Second,
+			expectedMessage:    "invalid range [1m0s, 1s]",
+			expectedFatalCalls: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+
+			DurationInRange(mockT, tt.got, tt.low, tt.high)
+			n := len(mockT.ErrorfCalls)
+			m := len(mockT.FatalfCalls)
+
+			if n != tt.expectedErrorCalls {
+				t.Fatalf("expected %d calls to Errorf(), got %d", tt.expectedErrorCalls, n)
+			}
+
+			if m != tt.expectedFatalCalls {
+				t.Fatalf("expected %d calls to Fatalf(), got %d", tt.expectedFatalCalls, m)
+			}
+
+			if mockT.HelperCalls != n+m {
+				t.Errorf("expected %d calls to Helper(), got %d", n+m, mockT.HelperCalls)
+			}
+
+			if n > 0 && mockT.ErrorfCalls[0].message() != tt.expectedMessage {
+				t.Errorf("expected message:\n%s\ngot:\n%s", tt.expectedMessage, mockT.ErrorfCalls[0].message())
+			}
+
+			if m > 0 && mockT.FatalfCalls[0].message() != tt.expectedMessage {
+				t.Errorf("expected message:\n%s\ngot:\n%s", tt.expectedMessage, mockT.FatalfCalls[0].message())
+			}
+		})
+	}
+}
+
+func TestTimeInLocation(t *testing.T) {
+	tests := []struct {
+		name            string
+		got             time.Time
+		loc             *time.Location
+		expectedMessage string
+		expectedCalls   int
+	}{
+		{name: "Same location", got: testTime.In(newYork), loc: time.FixedZone("EST", 0)},
+		{
+			name:            "Different location",
+			got:             testTime,
+			loc:             newYork,
+			expectedMessage: "expected 2024-03-09T23:30:00.0000005Z to be in location EST, got UTC",
+			expectedCalls:   1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+
+			TimeInLocation(mockT, tt.got, tt.loc)
+			n := len(mockT.ErrorfCalls)
+
+			if n != tt.expectedCalls {
+				t.Fatalf("expected %d calls to Errorf(), got %d", tt.expectedCalls, n)
+			}
+
+			if n != mockT.HelperCalls {
+				t.Errorf("expected %d calls to Helper(), got %d", n, mockT.HelperCalls)
+			}
+
+			if n > 0 && mockT.ErrorfCalls[0].message() != tt.expectedMessage {
+				t.Errorf("expected message:\n%s\ngot:\n%s", tt.expectedMessage, mockT.ErrorfCalls[0].message())
+			}
+		})
+	}
+}
@@ -0,0 +1,607 @@
+package assert
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+// TOMLEqual asserts that two TOML documents are semantically equivalent,
+// ignoring formatting, comments, and the order of keys and tables.
+// Differences are reported by path in the same way as DeepEqual, except that
+// NaN values are equal to each other.
+//
+// TOML is parsed by a minimal built-in parser for TOML 1.0, with integers
+// decoded as int64 and floats as float64. Offset date-times are decoded as a
+// time.Time in UTC, so date-times describing the same instant are equal,
+// while local date-times, dates, and times are compared by their value.
+func TOMLEqual[T ~string | ~[]byte](t testing.TB, got, expected T) {
+	e, err := parseTOML([]byte(expected))
+	if err != nil {
+		t.Helper()
+		t.Fatalf("failed to parse expected TOML: %v", err)
+		return
+	}
+
+	g, err := parseTOML([]byte(got))
+	if err != nil {
+		t.Helper()
+		t.Errorf("failed to parse TOML: %v", err)
+		return
+	}
+
+	if diff := diffDocuments(e, g); diff != "" {
+		t.Helper()
+		t.Errorf("TOML documents are not equal:\n%s", indent(diff, "\t"))
+	}
+}
+
+// tomlLocalDateTime, tomlLocalDate, and tomlLocalTime hold TOML date-times
+// without an offset, which do not describe an instant, in a canonical form.
+type (
+	tomlLocalDateTime string
+	tomlLocalDate     string
+	tomlLocalTime     string
+)
+
+// tomlParser parses a TOML document.
+type tomlParser struct {
+	s string
+	i int
+	// defined holds the paths of the tables defined by [table] headers, which
+	// may only be defined once.
+	defined map[string]bool
+}
+
+// parseTOML decodes a TOML document into a generic tree of map[string]any,
+// []any, string, int64, float64, bool, and date-time values.
+func parseTOML(data []byte) (map[string]any, error) {
+	p := &tomlParser{
+		s:       string(bytes.TrimPrefix(normalizeNewlines(data), []byte("\ufeff"))),
+		defined: make(map[string]bool),
+	}
+
+	root := make(map[string]any)
+	if err := p.parse(root); err != nil {
+		return nil, fmt.Errorf("line %d: %v", strings.Count(p.s[:p.i], "\n")+1, err)
+	}
+	return root, nil
+}
+
+func (p *tomlParser) parse(root map[string]any) error {
+	table := root
+	for {
+		p.skipSpace(true)
+		if p.i == len(p.s) {
+			return nil
+		}
+
+		var err error
+		switch {
+		case strings.HasPrefix(p.s[p.i:], "[["):
+			p.i += 2
+			table, err = p.arrayTableHeader(root)
+		case p.s[p.i] == '[':
+			p.i++
+			table, err = p.tableHeader(root)
+		default:
+			err = p.keyValue(table)
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := p.endOfLine(); err != nil {
+			return err
+		}
+	}
+}
+
+// tableHeader parses a [table] header and returns the table it defines.
+func (p *tomlParser) tableHeader(root map[string]any) (map[string]any, error) {
+	keys, err := p.header("]")
+	if err != nil {
+		return nil, err
+	}
+
+	path := tomlPath(keys)
+	if p.defined[path] {
+		return nil, fmt.Errorf("table %q is already defined", strings.Join(keys, "."))
+	}
+	p.defined[path] = true
+
+	return tomlTable(root, keys)
+}
+
+// arrayTableHeader parses an [[array]] header and returns the table it
+// appends to the array.
+func (p *tomlParser) arrayTableHeader(root map[string]any) (map[string]any, error) {
+	keys, err := p.header("]]")
+	if err != nil {
+		return nil, err
+	}
+
+	parent, err := tomlTable(root, keys[:len(keys)-1])
+	if err != nil {
+		return nil, err
+	}
+
+	last := keys[len(keys)-1]
+	arr, ok := parent[last].([]any)
+	if _, exists := parent[last]; exists && !ok {
+		return nil, fmt.Errorf("key %q is already defined and is not an array of tables", last)
+	}
+
+	table := make(map[string]any)
+	parent[last] = append(arr, table)
+
+	// The tables below the previous table of the array may be defined again
+	// below the new one.
+	prefix := tomlPath(keys) + "\x00"
+	for path := range p.defined {
+		if strings.HasPrefix(path, prefix) {
+			delete(p.defined, path)
+		}
+	}
+
+	return table, nil
+}
+
+// tomlPath joins the keys of a table header into a path that cannot be
+// confused with that of other keys.
+func tomlPath(keys []string) string {
+	return strings.Join(keys, "\x00")
+}
+
+func (p *tomlParser) header(end string) ([]string, error) {
+	keys, err := p.key()
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipSpace(false)
+	if !strings.HasPrefix(p.s[p.i:], end) {
+		return nil, fmt.Errorf("expected %q after table name", end)
+	}
+	p.i += len(end)
+
+	return keys, nil
+}
+
+// tomlTable returns the table at the path of keys, creating any missing
+// tables. The path continues through the last table of arrays of tables.
+func tomlTable(table map[string]any, keys []string) (map[string]any, error) {
+	for _, k := range keys {
+		switch v := table[k].(type) {
+		case nil:
+			next := make(map[string]any)
+			table[k] = next
+			table = next
+		case map[string]any:
+			table = v
+		case []any:
+			var next map[string]any
+			if len(v) > 0 {
+				next, _ = v[len(v)-1].(map[string]any)
+			}
+			if next == nil {
+				return nil, fmt.Errorf("key %q is already defined and is not a table", k)
+			}
+			table = next
+		default:
+			return nil, fmt.Errorf("key %q is already defined and is not a table", k)
+		}
+	}
+	return table, nil
+}
+
+// keyValue parses a key/value pair and adds it to table.
+func (p *tomlParser) keyValue(table map[string]any) error {
+	keys, err := p.key()
+	if err != nil {
+		return err
+	}
+
+	p.skipSpace(false)
+	if p.i == len(p.s) || p.s[p.i] != '=' {
+		return errors.New("expected '=' after key")
+	}
+	p.i++
+	p.skipSpace(false)
+
+	v, err := p.value()
+	if err != nil {
+		return err
+	}
+
+	parent, err := tomlTable(table, keys[:len(keys)-1])
+	if err != nil {
+		return err
+	}
+
+	last := keys[len(keys)-1]
+	if _, ok := parent[last]; ok {
+		return fmt.Errorf("duplicate key %q", strings.Join(keys, "."))
+	}
+	parent[last] = v
+
+	return nil
+}
+
+// key parses a possibly dotted key.
+func (p *tomlParser) key() ([]string, error) {
+	var keys []string
+	for {
+		p.skipSpace(false)
+
+		var (
+			k   string
+			err error
+		)
+
+		switch {
+		case strings.HasPrefix(p.s[p.i:], `"`):
+			k, err = p.basicString()
+		case strings.HasPrefix(p.s[p.i:], "'"):
+			k, err = p.literalString()
+		default:
+			start := p.i
+			for p.i < len(p.s) && isTOMLBareKeyChar(p.s[p.i]) {
+				p.i++
+			}
+			if p.i == start {
+				return nil, errors.New("expected key")
+			}
+			k = p.s[start:p.i]
+		}
+
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, k)
+
+		p.skipSpace(false)
+		if p.i == len(p.s) || p.s[p.i] != '.' {
+			return keys, nil
+		}
+		p.i++
+	}
+}
+
+func (p *tomlParser) value() (any, error) {
+	rest := p.s[p.i:]
+	switch {
+	case strings.HasPrefix(rest, `"""`):
+		return p.multilineString('"')
+	case strings.HasPrefix(rest, "'''"):
+		return p.multilineString('\'')
+	case strings.HasPrefix(rest, `"`):
+		return p.basicString()
+	case strings.HasPrefix(rest, "'"):
+		return p.literalString()
+	case strings.HasPrefix(rest, "["):
+		return p.array()
+	case strings.HasPrefix(rest, "{"):
+		return p.inlineTable()
+	}
+
+	return parseTOMLScalar(p.token())
+}
+
+func (p *tomlParser) array() (any, error) {
+	p.i++
+
+	arr := []any{}
+	for {
+		p.skipSpace(true)
+		if p.i == len(p.s) {
+			return nil, errors.New("unterminated array")
+		}
+		if p.s[p.i] == ']' {
+			p.i++
+			return arr, nil
+		}
+
+		v, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		arr = append(arr, v)
+
+		p.skipSpace(true)
+		if p.i < len(p.s) && p.s[p.i] == ',' {
+			p.i++
+		} else if p.i < len(p.s) && p.s[p.i] != ']' {
+			return nil, fmt.Errorf("expected ',' or ']' in array, got %q", p.s[p.i])
+		}
+	}
+}
+
+func (p *tomlParser) inlineTable() (any, error) {
+	p.i++
+
+	table := make(map[string]any)
+	for {
+		p.skipSpace(false)
+		if p.i == len(p.s) || p.s[p.i] == '\n' {
+			return nil, errors.New("unterminated inline table")
+		}
+		if p.s[p.i] == '}' {
+			p.i++
+			return table, nil
+		}
+
+		if err := p.keyValue(table); err != nil {
+			return nil, err
+		}
+
+		p.skipSpace(false)
+		if p.i < len(p.s) && p.s[p.i] == ',' {
+			p.i++
+		} else if p.i < len(p.s) && p.s[p.i] != '}' {
+			return nil, fmt.Errorf("expected ',' or '}' in inline table, got %q", p.s[p.i])
+		}
+	}
+}
+
+// tomlEscapes maps the single character escape sequences of TOML basic
+// strings to the runes they represent.
+var tomlEscapes = map[byte]rune{
+	'b': '\b', 't': '\t', 'n': '\n', 'f': '\f', 'r': '\r', 'e': '\x1b', '"': '"', '\\': '\\',
+}
+
+func (p *tomlParser) basicString() (string, error) {
+	p.i++
+
+	var b []byte
+	for p.i < len(p.s) {
+		switch c := p.s[p.i]; c {
+		case '"':
+			p.i++
+			return string(b), nil
+		case '\n':
+			return "", errors.New("unterminated string")
+		case '\\':
+			if p.i+1 == len(p.s) {
+				return "", errors.New("unterminated string")
+			}
+
+			r, n, err := parseEscape(p.s[p.i+1:], tomlEscapes)
+			if err != nil {
+				return "", err
+			}
+			b = utf8.AppendRune(b, r)
+			p.i += 1 + n
+		default:
+			b = append(b, c)
+			p.i++
+		}
+	}
+	return "", errors.New("unterminated string")
+}
+
+func (p *tomlParser) literalString() (string, error) {
+	p.i++
+
+	end := strings.IndexAny(p.s[p.i:], "'\n")
+	if end < 0 || p.s[p.i+end] == '\n' {
+		return "", errors.New("unterminated string")
+	}
+
+	s := p.s[p.i : p.i+end]
+	p.i += end + 1
+	return s, nil
+}
+
+// multilineString parses a multi-line basic or literal string, delimited by
+// three quotes. A line break directly after the opening quotes is trimmed.
+func (p *tomlParser) multilineString(quote byte) (string, error) {
+	p.i += 3
+	if p.i < len(p.s) && p.s[p.i] == '\n' {
+		p.i++
+	}
+
+	delim := strings.Repeat(string(quote), 3)
+
+	var b []byte
+	for p.i < len(p.s) {
+		c := p.s[p.i]
+		switch {
+		case strings.HasPrefix(p.s[p.i:], delim):
+			// Up to two quotes directly before the closing delimiter are
+			// part of the string.
+			n := 3
+			for n < 5 && p.i+n < len(p.s) && p.s[p.i+n] == quote {
+				n++
+			}
+			b = append(b, p.s[p.i:p.i+n-3]...)
+			p.i += n
+			return string(b), nil
+		case c == '\\' && quote == '"':
+			if p.i+1 == len(p.s) {
+				return "", errors.New("unterminated string")
+			}
+
+			// A backslash at the end of a line trims the line break and
+			// any whitespace that follows it.
+			if rest := strings.TrimLeft(p.s[p.i+1:], " \t"); strings.HasPrefix(rest, "\n") {
+				p.i = len(p.s) - len(strings.TrimLeft(rest, " \t\n"))
+				continue
+			}
+
+			r, n, err := parseEscape(p.s[p.i+1:], tomlEscapes)
+			if err != nil {
+				return "", err
+			}
+			b = utf8.AppendRune(b, r)
+			p.i += 1 + n
+		default:
+			b = append(b, c)
+			p.i++
+		}
+	}
+	return "", errors.New("unterminated string")
+}
+
+var tomlDate = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+
+// token reads the text of a number, boolean, or date-time.
+func (p *tomlParser) token() string {
+	start := p.i
+	for p.i < len(p.s) && isTOMLTokenChar(p.s[p.i]) {
+		p.i++
+	}
+
+	// The date and time of a date-time may be separated by a space.
+	if tomlDate.MatchString(p.s[start:p.i]) && p.i+3 < len(p.s) && p.s[p.i] == ' ' &&
+		isDigit(p.s[p.i+1]) && isDigit(p.s[p.i+2]) && p.s[p.i+3] == ':' {
+		p.i++
+		for p.i < len(p.s) && isTOMLTokenChar(p.s[p.i]) {
+			p.i++
+		}
+	}
+
+	return p.s[start:p.i]
+}
+
+var (
+	tomlInteger  = regexp.MustCompile(`^[+-]?[0-9]+$`)
+	tomlFloat    = regexp.MustCompile(`^[+-]?[0-9]+(\.[0-9]+)?([eE][+-]?[0-9]+)?$`)
+	tomlDateTime = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2})[Tt ](\d{2}:\d{2}:\d{2}(\.\d+)?)([Zz]|[+-]\d{2}:\d{2})?$`)
+	tomlTime     = regexp.MustCompile(`^\d{2}:\d{2}:\d{2}(\.\d+)?$`)
+)
+
+// parseTOMLScalar parses the value of a number, boolean, or date-time.
+func parseTOMLScalar(tok string) (any, error) {
+	switch tok {
+	case "":
+		return nil, errors.New("expected value")
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "inf", "+inf":
+		return math.Inf(1), nil
+	case "-inf":
+		return math.Inf(-1), nil
+	case "nan", "+nan", "-nan":
+		return math.NaN(), nil
+	}
+
+	if m := tomlDateTime.FindStringSubmatch(tok); m != nil {
+		s := m[1] + "T" + m[2]
+		if m[4] == "" {
+			t, err := time.Parse("2006-01-02T15:04:05.999999999", s)
+			if err != nil {
+				return nil, fmt.Errorf("invalid date-time %q", tok)
+			}
+			return tomlLocalDateTime(t.Format("2006-01-02T15:04:05.999999999")), nil
+		}
+
+		t, err := time.Parse(time.RFC3339Nano, s+strings.ToUpper(m[4]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid date-time %q", tok)
+		}
+		return t.UTC(), nil
+	}
+
+	if tomlDate.MatchString(tok) {
+		if _, err := time.Parse("2006-01-02", tok); err != nil {
+			return nil, fmt.Errorf("invalid date %q", tok)
+		}
+		return tomlLocalDate(tok), nil
+	}
+
+	if tomlTime.MatchString(tok) {
+		t, err := time.Parse("15:04:05.999999999", tok)
+		if err != nil {
+			return nil, fmt.Errorf("invalid time %q", tok)
+		}
+		return tomlLocalTime(t.Format("15:04:05.999999999")), nil
+	}
+
+	s := strings.ReplaceAll(tok, "_", "")
+	for prefix, base := range map[string]int{"0x": 16, "0o": 8, "0b": 2} {
+		if strings.HasPrefix(s, prefix) {
+			n, err := strconv.ParseInt(s[2:], base, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid integer %q", tok)
+			}
+			return n, nil
+		}
+	}
+
+	switch {
+	case tomlInteger.MatchString(s):
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid integer %q", tok)
+		}
+		return n, nil
+	case tomlFloat.MatchString(s):
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid float %q", tok)
+		}
+		return f, nil
+	}
+
+	return nil, fmt.Errorf("invalid value %q", tok)
+}
+
+// skipSpace skips whitespace. If newlines is true, line breaks and comments
+// are skipped as well.
+func (p *tomlParser) skipSpace(newlines bool) {
+	for p.i < len(p.s) {
+		switch c := p.s[p.i]; {
+		case c == ' ' || c == '\t':
+			p.i++
+		case newlines && c == '\n':
+			p.i++
+		case newlines && c == '#':
+			for p.i < len(p.s) && p.s[p.i] != '\n' {
+				p.i++
+			}
+		default:
+			return
+		}
+	}
+}
+
+// endOfLine consumes the rest of a line, which may only hold a comment.
+func (p *tomlParser) endOfLine() error {
+	p.skipSpace(false)
+	if p.i < len(p.s) && p.s[p.i] == '#' {
+		for p.i < len(p.s) && p.s[p.i] != '\n' {
+			p.i++
+		}
+	}
+
+	if p.i < len(p.s) && p.s[p.i] != '\n' {
+		rest := p.s[p.i:]
+		if end := strings.IndexByte(rest, '\n'); end >= 0 {
+			rest = rest[:end]
+		}
+		return fmt.Errorf("unexpected %q at end of line", rest)
+	}
+	return nil
+}
+
+func isTOMLBareKeyChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || isDigit(c) || c == '_' || c == '-'
+}
+
+func isTOMLTokenChar(c byte) bool {
+	return isTOMLBareKeyChar(c) || c == '+' || c == '.' || c == ':'
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
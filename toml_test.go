@@ -0,0 +1,237 @@
+package assert
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTOMLEqual(t *testing.T) {
+	tests := []struct {
+		name               string
+		got                string
+		expected           string
+		expectedMessage    string
+		expectedErrorCalls int
+		expectedFatalCalls int
+	}{
+		{
+			name: "Formatting, comments, and table order",
+			got: `
+title = "app" # comment
+
+[server]
+port = 8080
+
+[database]
+hosts = [ "a", "b" ]
+`,
+			expected: `database.hosts = ["a", "b"]
+title = 'app'
+server = { port = 8080 }
+`,
+		},
+		{
+			name:     "Equal instants",
+			got:      "at = 2022-03-26T10:00:00+02:00",
+			expected: "at = 2022-03-26T08:00:00Z",
+		},
+		{
+			name:     "NaN and infinities",
+			got:      "a = nan\nb = [inf, -inf]\n",
+			expected: "a = +nan\nb = [+inf, -inf]\n",
+		},
+		{
+			name:               "NaN and number",
+			got:                "a = nan\n",
+			expected:           "a = 1.5\n",
+			expectedMessage:    "TOML documents are not equal:\n\t[\"a\"]: expected 1.5, got NaN",
+			expectedErrorCalls: 1,
+		},
+		{
+			name:               "Differing values",
+			got:                "[server]\nport = 8081\nhost = 'localhost'\n",
+			expected:           "[server]\nport = 8080\nhost = 'localhost'\n",
+			expectedMessage:    "TOML documents are not equal:\n\t[\"server\"][\"port\"]: expected 8080, got 8081",
+			expectedErrorCalls: 1,
+		},
+		{
+			name:               "Missing table",
+			got:                "[[servers]]\nname = 'a'\n",
+			expected:           "[[servers]]\nname = 'a'\n[[servers]]\nname = 'b'\n",
+			expectedMessage:    "TOML documents are not equal:\n\t[\"servers\"][1]: missing element map[string]interface {}{\"name\": \"b\"}",
+			expectedErrorCalls: 1,
+		},
+		{
+			name:               "Invalid got",
+			got:                "a = ",
+			expected:           "a = 1",
+			expectedErrorCalls: 1,
+		},
+		{
+			name:               "Invalid expected",
+			got:                "a = 1",
+			expected:           "a = 1\na = 2",
+			expectedFatalCalls: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+
+			TOMLEqual(mockT, []byte(tt.got), []byte(tt.expected))
+			n := len(mockT.ErrorfCalls)
+			m := len(mockT.FatalfCalls)
+
+			if n != tt.expectedErrorCalls {
+				t.Fatalf("expected %d calls to Errorf(), got %d", tt.expectedErrorCalls, n)
+			}
+
+			if m != tt.expectedFatalCalls {
+				t.Errorf("expected %d calls to Fatalf(), got %d", tt.expectedFatalCalls, m)
+			}
+
+			if mockT.HelperCalls != n+m {
+				t.Errorf("expected %d calls to Helper(), got %d", n+m, mockT.HelperCalls)
+			}
+
+			if tt.expectedMessage != "" && mockT.ErrorfCalls[0].message() != tt.expectedMessage {
+				t.Errorf("expected message:\n%s\ngot:\n%s", tt.expectedMessage, mockT.ErrorfCalls[0].message())
+			}
+		})
+	}
+}
+
+func TestParseTOML(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected map[string]any
+		err      string
+	}{
+		{
+			name: "Values",
+			input: `
+int = +1_000
+hex = 0xff
+oct = 0o17
+bin = 0b101
+float = 6.25e-1
+inf = -inf
+bool = true
+arr = [
+  1,
+  [2, 3], # comment
+]
+`,
+			expected: map[string]any{
+				"int":   int64(1000),
+				"hex":   int64(255),
+				"oct":   int64(15),
+				"bin":   int64(5),
+				"float": 0.625,
+				"inf":   math.Inf(-1),
+				"bool":  true,
+				"arr":   []any{int64(1), []any{int64(2), int64(3)}},
+			},
+		},
+		{
+			name:  "Strings",
+			input: "basic = \"tab\\t\\u00e9\"\nliteral = 'C:\\path'\nmulti = \"\"\"\nline 1\\\n    continued\nline 2\"\"\"\"\nraw = '''\n'quoted'\n'''\n\"quoted key\" = 1\n",
+			expected: map[string]any{
+				"basic":      "tab\té",
+				"literal":    `C:\path`,
+				"multi":      "line 1continued\nline 2\"",
+				"raw":        "'quoted'\n",
+				"quoted key": int64(1),
+			},
+		},
+		{
+			name:  "Date-times",
+			input: "odt = 1979-05-27 07:32:00.500-07:00\nldt = 1979-05-27T07:32:00.50\nld = 1979-05-27\nlt = 07:32:00\n",
+			expected: map[string]any{
+				"odt": time.Date(1979, 5, 27, 14, 32, 0, 500000000, time.UTC),
+				"ldt": tomlLocalDateTime("1979-05-27T07:32:00.5"),
+				"ld":  tomlLocalDate("1979-05-27"),
+				"lt":  tomlLocalTime("07:32:00"),
+			},
+		},
+		{
+			name: "Tables",
+			input: `
+a.b = 1
+[x.y]
+z = { p = 1, q.r = 2 }
+[[items]]
+id = 1
+[items.meta]
+tag = "a"
+[[items]]
+id = 2
+`,
+			expected: map[string]any{
+				"a": map[string]any{"b": int64(1)},
+				"x": map[string]any{"y": map[string]any{
+					"z": map[string]any{"p": int64(1), "q": map[string]any{"r": int64(2)}},
+				}},
+				"items": []any{
+					map[string]any{"id": int64(1), "meta": map[string]any{"tag": "a"}},
+					map[string]any{"id": int64(2)},
+				},
+			},
+		},
+		{
+			name:  "Subtables of arrays of tables",
+			input: "[[a]]\n[a.b]\nx = 1\n[[a]]\n[a.b]\nx = 2\n",
+			expected: map[string]any{"a": []any{
+				map[string]any{"b": map[string]any{"x": int64(1)}},
+				map[string]any{"b": map[string]any{"x": int64(2)}},
+			}},
+		},
+		{
+			name:  "Duplicate key",
+			input: "a = 1\n[t]\nb = 2\nb = 3\n",
+			err:   `line 4: duplicate key "b"`,
+		},
+		{
+			name:  "Table defined twice",
+			input: "[a]\nb = 1\n[c]\n[a]\nd = 2\n",
+			err:   `line 4: table "a" is already defined`,
+		},
+		{
+			name:  "Key is not a table",
+			input: "a = 1\n[a.b]\n",
+			err:   `line 2: key "a" is already defined and is not a table`,
+		},
+		{
+			name:  "Trailing content",
+			input: "a = 1 2\n",
+			err:   `line 1: unexpected "2" at end of line`,
+		},
+		{
+			name:  "Invalid value",
+			input: "a = yes\n",
+			err:   `line 1: invalid value "yes"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := parseTOML([]byte(tt.input))
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Fatalf("expected error %q, got %v", tt.err, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(doc, tt.expected) {
+				t.Errorf("unexpected document:\n%s", diffValues(tt.expected, doc))
+			}
+		})
+	}
+}
@@ -0,0 +1,431 @@
+package assert
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// XMLEqual asserts that two XML documents are structurally equal. Leading and
+// trailing whitespace of text is ignored, as are the order of attributes and
+// the prefixes bound to namespaces, with elements and attributes compared by
+// their namespace URI instead. Comments, processing instructions, and
+// directives are ignored. The XPath of the first difference is reported.
+func XMLEqual[T ~string | ~[]byte](t testing.TB, got, expected T) {
+	e, err := parseMarkup([]byte(expected), false)
+	if err != nil {
+		t.Helper()
+		t.Fatalf("failed to parse expected XML: %v", err)
+		return
+	}
+
+	g, err := parseMarkup([]byte(got), false)
+	if err != nil {
+		t.Helper()
+		t.Errorf("failed to parse XML: %v", err)
+		return
+	}
+
+	if d := diffMarkup(e, g); d != nil {
+		t.Helper()
+		t.Errorf("XML documents are not equal:\n\t%s", d)
+	}
+}
+
+// HTMLEqual asserts that two HTML documents are structurally equal. Runs of
+// whitespace in text are treated as a single space, except within pre and
+// textarea elements, and whitespace at the start or end of text is ignored.
+// The order of attributes is ignored, as are comments and the doctype. The
+// XPath of the first difference is reported.
+//
+// HTML is parsed with encoding/xml in its non-strict mode, which accepts void
+// elements, attributes without values or quotes, HTML entities, and case
+// insensitive names. The content of script and style elements is read as raw
+// text up to their end tag. Other end tags should not be omitted.
+func HTMLEqual[T ~string | ~[]byte](t testing.TB, got, expected T) {
+	e, err := parseMarkup([]byte(expected), true)
+	if err != nil {
+		t.Helper()
+		t.Fatalf("failed to parse expected HTML: %v", err)
+		return
+	}
+
+	g, err := parseMarkup([]byte(got), true)
+	if err != nil {
+		t.Helper()
+		t.Errorf("failed to parse HTML: %v", err)
+		return
+	}
+
+	if d := diffMarkup(e, g); d != nil {
+		t.Helper()
+		t.Errorf("HTML documents are not equal:\n\t%s", d)
+	}
+}
+
+// markupNode is the document, an element, or a text node of an XML or HTML
+// document.
+type markupNode struct {
+	name   xml.Name
+	attrs  []xml.Attr
+	isText bool
+	// raw holds the text of a text node as it appears in the document, while
+	// text holds it with insignificant whitespace removed.
+	raw      string
+	text     string
+	parent   *markupNode
+	children []*markupNode
+}
+
+func (n *markupNode) isElement() bool {
+	return !n.isText && n.parent != nil
+}
+
+// attr returns the value of the attribute with the given local name.
+func (n *markupNode) attr(name string) (string, bool) {
+	for _, a := range n.attrs {
+		if a.Name.Local == name {
+			return a.Value, true
+		}
+	}
+	return "", false
+}
+
+// significant returns the children of a node that are compared, which are
+// its elements and text nodes that are not entirely insignificant whitespace.
+func (n *markupNode) significant() []*markupNode {
+	var children []*markupNode
+	for _, c := range n.children {
+		if !c.isText || c.text != "" {
+			children = append(children, c)
+		}
+	}
+	return children
+}
+
+// elements returns the element children of a node.
+func (n *markupNode) elements() []*markupNode {
+	var children []*markupNode
+	for _, c := range n.children {
+		if !c.isText {
+			children = append(children, c)
+		}
+	}
+	return children
+}
+
+// textContent returns the text of a node and its descendants, with runs of
+// whitespace collapsed into a single space.
+func (n *markupNode) textContent() string {
+	var b strings.Builder
+
+	var walk func(*markupNode)
+	walk = func(n *markupNode) {
+		if n.isText {
+			b.WriteString(n.raw)
+		}
+		for _, c := range n.children {
+			walk(c)
+		}
+	}
+	walk(n)
+
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// xpath returns the XPath locating a node within its document. Positions are
+// only given for nodes that have siblings of the same name.
+func (n *markupNode) xpath() string {
+	if n.parent == nil {
+		return "/"
+	}
+
+	step := n.name.Local
+	if n.isText {
+		step = "text()"
+	}
+
+	var count, position int
+	for _, s := range n.parent.significant() {
+		if s.isText == n.isText && s.name == n.name {
+			count++
+		}
+		if s == n {
+			position = count
+		}
+	}
+	if count > 1 {
+		step += "[" + strconv.Itoa(position) + "]"
+	}
+
+	if n.parent.parent == nil {
+		return "/" + step
+	}
+	return n.parent.xpath() + "/" + step
+}
+
+// describe returns a short description of a node for failure messages.
+func (n *markupNode) describe() string {
+	if n.isText {
+		return "text " + strconv.Quote(n.text)
+	}
+	return "element <" + markupName(n.name) + ">"
+}
+
+// markupName returns an element or attribute name, qualified by its namespace
+// URI if it has one.
+func markupName(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+	return "{" + name.Space + "}" + name.Local
+}
+
+// parseMarkup parses an XML or HTML document into a tree of nodes.
+func parseMarkup(data []byte, html bool) (*markupNode, error) {
+	if html {
+		data = escapeHTMLRawText(data)
+	}
+
+	d := xml.NewDecoder(bytes.NewReader(data))
+	if html {
+		d.Strict = false
+		d.AutoClose = xml.HTMLAutoClose
+		d.Entity = xml.HTMLEntity
+	}
+
+	root := &markupNode{}
+	cur := root
+	for {
+		tok, err := d.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			n := &markupNode{name: tok.Name, parent: cur}
+			for _, a := range tok.Attr {
+				// Namespace declarations only bind prefixes, which are
+				// already resolved into the names of elements and attributes.
+				if a.Name.Space == "xmlns" || a.Name.Space == "" && a.Name.Local == "xmlns" {
+					continue
+				}
+				n.attrs = append(n.attrs, a)
+			}
+
+			if html {
+				n.name.Local = strings.ToLower(n.name.Local)
+				for i := range n.attrs {
+					n.attrs[i].Name.Local = strings.ToLower(n.attrs[i].Name.Local)
+				}
+			}
+
+			sort.Slice(n.attrs, func(i, j int) bool {
+				return markupName(n.attrs[i].Name) < markupName(n.attrs[j].Name)
+			})
+
+			cur.children = append(cur.children, n)
+			cur = n
+		case xml.EndElement:
+			cur = cur.parent
+		case xml.CharData:
+			if last := len(cur.children) - 1; last >= 0 && cur.children[last].isText {
+				cur.children[last].raw += string(tok)
+			} else {
+				cur.children = append(cur.children, &markupNode{isText: true, raw: string(tok), parent: cur})
+			}
+		}
+	}
+
+	normalizeMarkup(root, html, false)
+	return root, nil
+}
+
+// htmlRawTextElements are the HTML elements whose content is raw text, which
+// may contain characters such as < without them starting markup.
+var htmlRawTextElements = []string{"script", "style"}
+
+// htmlRawTextEscaper escapes raw text so that encoding/xml reads it back as
+// the same text.
+var htmlRawTextEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// escapeHTMLRawText escapes the content of the raw text elements of an HTML
+// document, as encoding/xml would otherwise parse it as markup.
+func escapeHTMLRawText(data []byte) []byte {
+	var b bytes.Buffer
+	written := 0
+	for i := 0; i < len(data); {
+		j := bytes.IndexByte(data[i:], '<')
+		if j < 0 {
+			break
+		}
+		j += i
+
+		if bytes.HasPrefix(data[j:], []byte("<!--")) {
+			end := bytes.Index(data[j+4:], []byte("-->"))
+			if end < 0 {
+				break
+			}
+			i = j + 4 + end + 3
+			continue
+		}
+
+		name := htmlRawTextElement(data[j+1:])
+		if name == "" {
+			i = j + 1
+			continue
+		}
+
+		start := htmlStartTagEnd(data, j)
+		if start < 0 {
+			break
+		}
+		if data[start-2] == '/' {
+			i = start
+			continue
+		}
+
+		end := start
+		for end < len(data) && !hasHTMLTagPrefix(data[end:], "</"+name) {
+			end++
+		}
+
+		b.Write(data[written:start])
+		b.WriteString(htmlRawTextEscaper.Replace(string(data[start:end])))
+		written, i = end, end
+	}
+
+	if written == 0 {
+		return data
+	}
+	b.Write(data[written:])
+	return b.Bytes()
+}
+
+// htmlRawTextElement returns the name of the raw text element started by a
+// tag, without its leading <, or an empty string if it starts no such element.
+func htmlRawTextElement(tag []byte) string {
+	for _, name := range htmlRawTextElements {
+		if hasHTMLTagPrefix(tag, name) {
+			return name
+		}
+	}
+	return ""
+}
+
+// hasHTMLTagPrefix reports whether b starts with a tag name, ignoring case,
+// followed by the end of the name.
+func hasHTMLTagPrefix(b []byte, name string) bool {
+	if len(b) <= len(name) || !bytes.EqualFold(b[:len(name)], []byte(name)) {
+		return false
+	}
+
+	switch b[len(name)] {
+	case ' ', '\t', '\n', '\r', '\f', '/', '>':
+		return true
+	}
+	return false
+}
+
+// htmlStartTagEnd returns the offset just past the > ending the start tag at
+// offset i, or -1 if the tag is not terminated. A > within a quoted attribute
+// value does not end the tag.
+func htmlStartTagEnd(data []byte, i int) int {
+	var quote byte
+	for ; i < len(data); i++ {
+		switch c := data[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '>':
+			return i + 1
+		}
+	}
+	return -1
+}
+
+// normalizeMarkup sets the text of every text node below n with insignificant
+// whitespace removed.
+func normalizeMarkup(n *markupNode, html, preserve bool) {
+	preserve = preserve || html && (n.name.Local == "pre" || n.name.Local == "textarea")
+
+	for _, c := range n.children {
+		switch {
+		case !c.isText:
+			normalizeMarkup(c, html, preserve)
+		case preserve:
+			c.text = c.raw
+		case html:
+			c.text = strings.Join(strings.Fields(c.raw), " ")
+		default:
+			c.text = strings.TrimSpace(c.raw)
+		}
+	}
+}
+
+// diffMarkup returns the first difference between two documents, or nil if
+// they are equal.
+func diffMarkup(expected, got *markupNode) *difference {
+	if expected.isElement() {
+		if d := diffAttrs(expected, got); d != nil {
+			return d
+		}
+	}
+
+	e, g := expected.significant(), got.significant()
+	for i := 0; i < len(e) || i < len(g); i++ {
+		switch {
+		case i >= len(g):
+			return &difference{path: e[i].xpath(), detail: "missing " + e[i].describe()}
+		case i >= len(e):
+			return &difference{path: g[i].xpath(), detail: "unexpected " + g[i].describe()}
+		case e[i].isText != g[i].isText || e[i].name != g[i].name || e[i].isText && e[i].text != g[i].text:
+			return &difference{path: g[i].xpath(), expected: e[i].describe(), got: g[i].describe()}
+		}
+
+		if d := diffMarkup(e[i], g[i]); d != nil {
+			return d
+		}
+	}
+	return nil
+}
+
+// diffAttrs returns the first difference between the attributes of two
+// elements, which are sorted by name.
+func diffAttrs(expected, got *markupNode) *difference {
+	e, g := expected.attrs, got.attrs
+	for len(e) > 0 || len(g) > 0 {
+		switch {
+		case len(g) == 0 || len(e) > 0 && markupName(e[0].Name) < markupName(g[0].Name):
+			return &difference{
+				path:   got.xpath() + "/@" + markupName(e[0].Name),
+				detail: "missing attribute with value " + strconv.Quote(e[0].Value),
+			}
+		case len(e) == 0 || markupName(g[0].Name) < markupName(e[0].Name):
+			return &difference{
+				path:   got.xpath() + "/@" + markupName(g[0].Name),
+				detail: "unexpected attribute with value " + strconv.Quote(g[0].Value),
+			}
+		case e[0].Value != g[0].Value:
+			return &difference{
+				path:     got.xpath() + "/@" + markupName(e[0].Name),
+				expected: strconv.Quote(e[0].Value),
+				got:      strconv.Quote(g[0].Value),
+			}
+		}
+		e, g = e[1:], g[1:]
+	}
+	return nil
+}
@@ -0,0 +1,206 @@
+package assert
+
+import "testing"
+
+func TestXMLEqual(t *testing.T) {
+	tests := []struct {
+		name               string
+		got                string
+		expected           string
+		expectedMessage    string
+		expectedErrorCalls int
+		expectedFatalCalls int
+	}{
+		{
+			name: "Whitespace, attribute order, and comments",
+			got: `<?xml version="1.0"?>
+<order id="1" status="new">
+	<!-- comment -->
+	<item sku="a">  Apple </item>
+</order>`,
+			expected: `<order status="new" id="1"><item sku="a">Apple</item></order>`,
+		},
+		{
+			name:     "Namespace prefixes",
+			got:      `<soap:Envelope xmlns:soap="urn:soap"><soap:Body a:x="1" xmlns:a="urn:a"/></soap:Envelope>`,
+			expected: `<Envelope xmlns="urn:soap"><Body b:x="1" xmlns:b="urn:a"></Body></Envelope>`,
+		},
+		{
+			name:               "Differing text",
+			got:                `<a><b>1</b><b>3</b></a>`,
+			expected:           `<a><b>1</b><b>2</b></a>`,
+			expectedMessage:    "XML documents are not equal:\n\t/a/b[2]/text(): expected text \"2\", got text \"3\"",
+			expectedErrorCalls: 1,
+		},
+		{
+			name:               "Differing attribute",
+			got:                `<a><b id="2"/></a>`,
+			expected:           `<a><b id="1"/></a>`,
+			expectedMessage:    "XML documents are not equal:\n\t/a/b/@id: expected \"1\", got \"2\"",
+			expectedErrorCalls: 1,
+		},
+		{
+			name:               "Missing attribute",
+			got:                `<a/>`,
+			expected:           `<a id="1"/>`,
+			expectedMessage:    "XML documents are not equal:\n\t/a/@id: missing attribute with value \"1\"",
+			expectedErrorCalls: 1,
+		},
+		{
+			name:               "Differing namespace",
+			got:                `<a xmlns="urn:y"/>`,
+			expected:           `<a xmlns="urn:x"/>`,
+			expectedMessage:    "XML documents are not equal:\n\t/a: expected element <{urn:x}a>, got element <{urn:y}a>",
+			expectedErrorCalls: 1,
+		},
+		{
+			name:               "Unexpected element",
+			got:                `<a><b/><c/></a>`,
+			expected:           `<a><b/></a>`,
+			expectedMessage:    "XML documents are not equal:\n\t/a/c: unexpected element <c>",
+			expectedErrorCalls: 1,
+		},
+		{
+			name:               "Invalid got",
+			got:                `<a><b></a>`,
+			expected:           `<a/>`,
+			expectedErrorCalls: 1,
+		},
+		{
+			name:               "Invalid expected",
+			got:                `<a/>`,
+			expected:           `<a>`,
+			expectedFatalCalls: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+
+			XMLEqual(mockT, tt.got, tt.expected)
+			n := len(mockT.ErrorfCalls)
+			m := len(mockT.FatalfCalls)
+
+			if n != tt.expectedErrorCalls {
+				t.Fatalf("expected %d calls to Errorf(), got %d", tt.expectedErrorCalls, n)
+			}
+
+			if m != tt.expectedFatalCalls {
+				t.Errorf("expected %d calls to Fatalf(), got %d", tt.expectedFatalCalls, m)
+			}
+
+			if mockT.HelperCalls != n+m {
+				t.Errorf("expected %d calls to Helper(), got %d", n+m, mockT.HelperCalls)
+			}
+
+			if tt.expectedMessage != "" && mockT.ErrorfCalls[0].message() != tt.expectedMessage {
+				t.Errorf("expected message:\n%s\ngot:\n%s", tt.expectedMessage, mockT.ErrorfCalls[0].message())
+			}
+		})
+	}
+}
+
+func TestHTMLEqual(t *testing.T) {
+	tests := []struct {
+		name               string
+		got                string
+		expected           string
+		expectedMessage    string
+		expectedErrorCalls int
+	}{
+		{
+			name: "Whitespace, case, and void elements",
+			got: `<!DOCTYPE html>
+<HTML>
+  <body class=main>
+    <p>Hello,
+       <b>world</b>&nbsp;!</p>
+    <br>
+    <input disabled>
+  </body>
+</HTML>`,
+			expected: `<html><body class="main"><p>Hello, <b>world</b>` + " " + `!</p><br/><input disabled="disabled"/></body></html>`,
+		},
+		{
+			name:     "Script and style as raw text",
+			got:      `<SCRIPT type="text/javascript">if (a < b && c) { s = "<b>" }</SCRIPT><style>p > a { color: red }</style>`,
+			expected: `<script type='text/javascript'>if (a < b && c) { s = "<b>" }</script><style>p > a { color: red }</style>`,
+		},
+		{
+			name:               "Differing script",
+			got:                "<script>if (a < b) {}</script>",
+			expected:           "<script>if (a <= b) {}</script>",
+			expectedMessage:    "HTML documents are not equal:\n\t/script/text(): expected text \"if (a <= b) {}\", got text \"if (a < b) {}\"",
+			expectedErrorCalls: 1,
+		},
+		{
+			name:               "Preformatted text",
+			got:                "<pre>a  b</pre>",
+			expected:           "<pre>a b</pre>",
+			expectedMessage:    "HTML documents are not equal:\n\t/pre/text(): expected text \"a b\", got text \"a  b\"",
+			expectedErrorCalls: 1,
+		},
+		{
+			name:               "Differing element",
+			got:                "<ul><li>a</li><li><em>b</em></li></ul>",
+			expected:           "<ul><li>a</li><li><strong>b</strong></li></ul>",
+			expectedMessage:    "HTML documents are not equal:\n\t/ul/li[2]/em: expected element <strong>, got element <em>",
+			expectedErrorCalls: 1,
+		},
+		{
+			name:               "Missing text",
+			got:                "<p></p>",
+			expected:           "<p>text</p>",
+			expectedMessage:    "HTML documents are not equal:\n\t/p/text(): missing text \"text\"",
+			expectedErrorCalls: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+
+			HTMLEqual(mockT, tt.got, tt.expected)
+			n := len(mockT.ErrorfCalls)
+
+			if n != tt.expectedErrorCalls {
+				t.Fatalf("expected %d calls to Errorf(), got %d", tt.expectedErrorCalls, n)
+			}
+
+			if len(mockT.FatalfCalls) != 0 {
+				t.Errorf("expected 0 calls to Fatalf(), got %d", len(mockT.FatalfCalls))
+			}
+
+			if mockT.HelperCalls != n {
+				t.Errorf("expected %d calls to Helper(), got %d", n, mockT.HelperCalls)
+			}
+
+			if tt.expectedMessage != "" && mockT.ErrorfCalls[0].message() != tt.expectedMessage {
+				t.Errorf("expected message:\n%s\ngot:\n%s", tt.expectedMessage, mockT.ErrorfCalls[0].message())
+			}
+		})
+	}
+}
+
+func TestEscapeHTMLRawText(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "No raw text elements", input: "<p>a &amp; b</p>", expected: "<p>a &amp; b</p>"},
+		{name: "Script", input: "<script>a < b && c</script>", expected: "<script>a &lt; b &amp;&amp; c</script>"},
+		{name: "Case insensitive", input: "<STYLE>a > b</Style>", expected: "<STYLE>a &gt; b</Style>"},
+		{name: "Quoted > in attribute", input: `<script data-x="a>b">1<2</script>`, expected: `<script data-x="a>b">1&lt;2</script>`},
+		{name: "Other element with same prefix", input: "<scripts>1<2</scripts>", expected: "<scripts>1<2</scripts>"},
+		{name: "Self-closing", input: "<script/><p>1</p>", expected: "<script/><p>1</p>"},
+		{name: "Commented out", input: "<!-- <script> --><p>1</p>", expected: "<!-- <script> --><p>1</p>"},
+		{name: "Unterminated", input: "<script>a < b", expected: "<script>a &lt; b"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(escapeHTMLRawText([]byte(tt.input))); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,931 @@
+package assert
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// YAMLEqual asserts that two YAML streams are semantically equivalent,
+// ignoring formatting, comments, and the order of mapping keys. Streams
+// holding more than one document are compared document by document.
+// Differences are reported by path in the same way as DeepEqual, except that
+// NaN values are equal to each other.
+//
+// YAML is parsed by a minimal built-in parser supporting block and flow
+// collections, plain, quoted, literal, and folded scalars, anchors, aliases,
+// and merge keys. Scalars are resolved with the YAML 1.2 core schema, with
+// integers decoded as int64 and floats as float64. Mapping keys are compared
+// as strings.
+func YAMLEqual[T ~string | ~[]byte](t testing.TB, got, expected T) {
+	e, err := parseYAML([]byte(expected))
+	if err != nil {
+		t.Helper()
+		t.Fatalf("failed to parse expected YAML: %v", err)
+		return
+	}
+
+	g, err := parseYAML([]byte(got))
+	if err != nil {
+		t.Helper()
+		t.Errorf("failed to parse YAML: %v", err)
+		return
+	}
+
+	// Single documents are compared directly so that paths do not start with
+	// the index of the document.
+	var diff string
+	if len(e) == 1 && len(g) == 1 {
+		diff = diffDocuments(e[0], g[0])
+	} else {
+		diff = diffDocuments(e, g)
+	}
+
+	if diff != "" {
+		t.Helper()
+		t.Errorf("YAML documents are not equal:\n%s", indent(diff, "\t"))
+	}
+}
+
+// errYAMLUnterminated is returned when a flow collection or quoted scalar
+// continues past the end of the text being parsed.
+var errYAMLUnterminated = errors.New("unterminated flow collection or quoted scalar")
+
+// yamlLine is a line of a YAML document with its indentation removed.
+type yamlLine struct {
+	num    int
+	indent int
+	text   string
+}
+
+// yamlParser parses the nodes of a single YAML document.
+type yamlParser struct {
+	lines   []yamlLine
+	pos     int
+	anchors map[string]any
+}
+
+// parseYAML decodes a YAML stream into a generic tree of map[string]any,
+// []any, string, int64, float64, bool, and nil values for each document.
+func parseYAML(data []byte) ([]any, error) {
+	s := string(bytes.TrimPrefix(normalizeNewlines(data), []byte("\ufeff")))
+
+	var (
+		docs     = []any{}
+		lines    []yamlLine
+		explicit bool
+	)
+
+	flush := func() error {
+		if explicit || hasYAMLContent(lines) {
+			doc, err := parseYAMLDocument(lines)
+			if err != nil {
+				return err
+			}
+			docs = append(docs, doc)
+		}
+		lines, explicit = nil, false
+		return nil
+	}
+
+	for i, raw := range strings.Split(s, "\n") {
+		switch {
+		case raw == "---" || strings.HasPrefix(raw, "--- ") || strings.HasPrefix(raw, "---\t"):
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			explicit = true
+
+			// Content may follow the marker on the same line.
+			raw = strings.TrimLeft(raw[3:], " \t")
+		case raw == "..." || strings.HasPrefix(raw, "... "):
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			continue
+		case strings.HasPrefix(raw, "%") && !explicit && !hasYAMLContent(lines):
+			// Directives such as %YAML precede the document.
+			continue
+		}
+
+		text := strings.TrimLeft(raw, " ")
+		lines = append(lines, yamlLine{num: i + 1, indent: len(raw) - len(text), text: text})
+	}
+
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
+
+// hasYAMLContent reports whether any line holds more than a comment.
+func hasYAMLContent(lines []yamlLine) bool {
+	for _, l := range lines {
+		if stripYAMLComment(l.text) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func parseYAMLDocument(lines []yamlLine) (any, error) {
+	p := &yamlParser{lines: lines, anchors: make(map[string]any)}
+
+	v, err := p.parseNested(-1, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if l, ok := p.peek(); ok {
+		return nil, fmt.Errorf("line %d: unexpected %q", l.num, stripYAMLComment(l.text))
+	}
+	return v, nil
+}
+
+// peek returns the next line holding more than a comment, skipping any
+// before it.
+func (p *yamlParser) peek() (yamlLine, bool) {
+	for p.pos < len(p.lines) {
+		if l := p.lines[p.pos]; stripYAMLComment(l.text) != "" {
+			return l, true
+		}
+		p.pos++
+	}
+	return yamlLine{}, false
+}
+
+// parseNested parses the block node on the following lines that is indented
+// by more than parent. If sequence is true, a sequence indented by exactly
+// parent is also accepted, as is allowed for the values of a mapping. A
+// missing node is null.
+func (p *yamlParser) parseNested(parent int, sequence bool) (any, error) {
+	l, ok := p.peek()
+	if !ok || l.indent < parent {
+		return nil, nil
+	}
+
+	if l.indent == parent {
+		if sequence && isYAMLSequenceItem(stripYAMLComment(l.text)) {
+			return p.parseSequence(l.indent)
+		}
+		return nil, nil
+	}
+
+	return p.parseBlock(l)
+}
+
+// parseBlock parses the node starting at the current line.
+func (p *yamlParser) parseBlock(l yamlLine) (any, error) {
+	text := stripYAMLComment(l.text)
+	if strings.HasPrefix(text, "\t") {
+		return nil, fmt.Errorf("line %d: tabs are not allowed in indentation", l.num)
+	}
+
+	switch {
+	case isYAMLSequenceItem(text):
+		return p.parseSequence(l.indent)
+	case isYAMLKey(text):
+		return p.parseMapping(l.indent)
+	}
+	return p.parseValue(0, l.indent-1, true)
+}
+
+func (p *yamlParser) parseSequence(indent int) (any, error) {
+	seq := []any{}
+	for {
+		l, ok := p.peek()
+		if !ok || l.indent != indent || !isYAMLSequenceItem(stripYAMLComment(l.text)) {
+			return seq, nil
+		}
+
+		v, err := p.parseValue(1, indent, true)
+		if err != nil {
+			return nil, err
+		}
+		seq = append(seq, v)
+	}
+}
+
+func (p *yamlParser) parseMapping(indent int) (any, error) {
+	m := make(map[string]any)
+
+	var merges []any
+	var mergeLine int
+
+	for {
+		l, ok := p.peek()
+		if !ok || l.indent != indent {
+			break
+		}
+
+		key, offset, ok := splitYAMLKey(stripYAMLComment(l.text))
+		if !ok {
+			break
+		}
+
+		v, err := p.parseValue(offset, indent, false)
+		if err != nil {
+			return nil, err
+		}
+
+		if key == "<<" {
+			merges, mergeLine = append(merges, v), l.num
+			continue
+		}
+
+		if _, ok := m[key]; ok {
+			return nil, fmt.Errorf("line %d: duplicate key %q", l.num, key)
+		}
+		m[key] = v
+	}
+
+	// Keys of the mapping itself take precedence over merged keys, which in
+	// turn take precedence over those of mappings merged after them.
+	for _, merge := range merges {
+		if err := mergeYAML(m, merge); err != nil {
+			return nil, fmt.Errorf("line %d: %v", mergeLine, err)
+		}
+	}
+
+	return m, nil
+}
+
+// mergeYAML adds the entries of the mappings referenced by a merge key to m,
+// unless m already holds a value for them.
+func mergeYAML(m map[string]any, merge any) error {
+	sources, ok := merge.([]any)
+	if !ok {
+		sources = []any{merge}
+	}
+
+	for _, source := range sources {
+		sm, ok := source.(map[string]any)
+		if !ok {
+			return errors.New("merge key must reference a mapping or sequence of mappings")
+		}
+
+		for k, v := range sm {
+			if _, ok := m[k]; !ok {
+				m[k] = v
+			}
+		}
+	}
+	return nil
+}
+
+// parseValue parses the node starting at the given offset of the current
+// line. Nodes continuing onto the following lines must be indented by more
+// than parent. If compact is true, the node may be a block collection that
+// starts on the current line, such as a mapping within a sequence entry.
+func (p *yamlParser) parseValue(offset, parent int, compact bool) (any, error) {
+	l := p.lines[p.pos]
+	text := stripYAMLComment(l.text[offset:])
+	anchor, tag, rest := yamlProperties(text)
+	offset += len(text) - len(rest)
+
+	var (
+		v   any
+		err error
+	)
+
+	switch {
+	case rest == "":
+		p.pos++
+		v, err = p.parseNested(parent, !compact)
+	case compact && (isYAMLSequenceItem(rest) || isYAMLKey(rest)):
+		// Parse the remainder of the line as though it started a new line
+		// at the same column.
+		p.lines[p.pos] = yamlLine{num: l.num, indent: l.indent + offset, text: l.text[offset:]}
+		v, err = p.parseBlock(p.lines[p.pos])
+	case rest[0] == '|' || rest[0] == '>':
+		p.pos++
+		v, err = p.parseBlockScalar(rest, parent)
+		if err != nil {
+			err = fmt.Errorf("line %d: %v", l.num, err)
+		}
+	default:
+		v, err = p.parseInline(rest, parent, tag)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if anchor != "" {
+		p.anchors[anchor] = v
+	}
+	return v, nil
+}
+
+// parseInline parses a scalar or flow collection starting on the current line
+// and continuing onto any following lines that belong to it.
+func (p *yamlParser) parseInline(text string, parent int, tag string) (any, error) {
+	l := p.lines[p.pos]
+	p.pos++
+	start := p.pos
+
+	switch text[0] {
+	case '*':
+		v, ok := p.anchors[text[1:]]
+		if !ok {
+			return nil, fmt.Errorf("line %d: unknown anchor %q", l.num, text[1:])
+		}
+		return v, nil
+	case '[', '{', '"', '\'':
+		for {
+			f := &yamlFlow{s: text, anchors: p.anchors}
+			v, err := f.parse()
+			if errors.Is(err, errYAMLUnterminated) && p.pos < len(p.lines) {
+				text += "\n" + p.lines[p.pos].text
+				p.pos++
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %v", l.num, err)
+			}
+			return v, nil
+		}
+	}
+
+	// A block mapping cannot start on the line of another mapping's key,
+	// as in "a: b: c".
+	if isYAMLKey(text) {
+		return nil, fmt.Errorf("line %d: mapping values are not allowed here", l.num)
+	}
+
+	// Plain scalars continue onto more indented lines, with single line
+	// breaks folded into spaces.
+	var b strings.Builder
+	b.WriteString(text)
+
+	breaks := 0
+	for i := p.pos; i < len(p.lines); i++ {
+		next := p.lines[i]
+		s := stripYAMLComment(next.text)
+		if s == "" {
+			if next.text != "" {
+				break
+			}
+			breaks++
+			continue
+		}
+
+		if next.indent <= parent || isYAMLKey(s) {
+			break
+		}
+
+		if breaks == 0 {
+			b.WriteByte(' ')
+		} else {
+			b.WriteString(strings.Repeat("\n", breaks))
+		}
+		b.WriteString(s)
+		breaks, p.pos = 0, i+1
+	}
+
+	if tag == "!!str" || p.pos > start {
+		return b.String(), nil
+	}
+	return resolveYAMLScalar(b.String()), nil
+}
+
+// parseBlockScalar parses the content of a literal or folded block scalar,
+// which is made up of the following lines indented by more than parent.
+func (p *yamlParser) parseBlockScalar(header string, parent int) (string, error) {
+	literal := header[0] == '|'
+
+	var chomp rune
+	indent := -1
+	for _, c := range header[1:] {
+		switch {
+		case c == '-' || c == '+':
+			chomp = c
+		case c >= '1' && c <= '9':
+			indent = int(c - '0')
+			if parent > 0 {
+				indent += parent
+			}
+		default:
+			return "", fmt.Errorf("invalid block scalar header %q", header)
+		}
+	}
+
+	var lines []string
+	for ; p.pos < len(p.lines); p.pos++ {
+		l := p.lines[p.pos]
+		if strings.TrimSpace(l.text) == "" {
+			lines = append(lines, "")
+			continue
+		}
+
+		// The indentation of the content is that of its first line unless
+		// it is given by the header.
+		if indent < 0 {
+			if l.indent <= parent {
+				break
+			}
+			indent = l.indent
+		}
+		if l.indent < indent {
+			break
+		}
+
+		lines = append(lines, strings.Repeat(" ", l.indent-indent)+l.text)
+	}
+
+	trailing := 0
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+		trailing++
+	}
+
+	var s string
+	if literal {
+		s = strings.Join(lines, "\n")
+	} else {
+		s = foldYAMLLines(lines)
+	}
+
+	if len(lines) > 0 && chomp != '-' {
+		s += "\n"
+	}
+	if chomp == '+' {
+		s += strings.Repeat("\n", trailing)
+	}
+	return s, nil
+}
+
+// foldYAMLLines joins the lines of a folded block scalar. Line breaks between
+// lines of text are folded into spaces, while empty lines and more indented
+// lines keep their line breaks.
+func foldYAMLLines(lines []string) string {
+	moreIndented := func(l string) bool {
+		return strings.HasPrefix(l, " ") || strings.HasPrefix(l, "\t")
+	}
+
+	var b strings.Builder
+	for i, l := range lines {
+		if i > 0 {
+			prev := lines[i-1]
+			switch {
+			case prev == "" || moreIndented(prev) || moreIndented(l):
+				b.WriteByte('\n')
+			case l != "":
+				b.WriteByte(' ')
+			}
+		}
+		b.WriteString(l)
+	}
+	return b.String()
+}
+
+// yamlFlow parses flow collections and quoted scalars.
+type yamlFlow struct {
+	s       string
+	i       int
+	anchors map[string]any
+}
+
+func (f *yamlFlow) parse() (any, error) {
+	v, err := f.value()
+	if err != nil {
+		return nil, err
+	}
+
+	f.skip()
+	if f.i < len(f.s) {
+		return nil, fmt.Errorf("unexpected %q after value", f.s[f.i:])
+	}
+	return v, nil
+}
+
+// skip skips whitespace, line breaks, and comments.
+func (f *yamlFlow) skip() {
+	for f.i < len(f.s) {
+		switch c := f.s[f.i]; {
+		case c == ' ' || c == '\t' || c == '\n':
+			f.i++
+		case c == '#' && (f.i == 0 || isYAMLSpace(f.s[f.i-1])):
+			for f.i < len(f.s) && f.s[f.i] != '\n' {
+				f.i++
+			}
+		default:
+			return
+		}
+	}
+}
+
+func (f *yamlFlow) value() (any, error) {
+	f.skip()
+
+	var anchor, tag string
+	for f.i < len(f.s) && (f.s[f.i] == '&' || f.s[f.i] == '!') {
+		start := f.i
+		for f.i < len(f.s) && !isYAMLFlowIndicator(f.s[f.i]) && !isYAMLSpace(f.s[f.i]) {
+			f.i++
+		}
+
+		if f.s[start] == '&' {
+			anchor = f.s[start+1 : f.i]
+		} else {
+			tag = f.s[start:f.i]
+		}
+		f.skip()
+	}
+
+	v, err := f.node(tag)
+	if err != nil {
+		return nil, err
+	}
+
+	if anchor != "" {
+		f.anchors[anchor] = v
+	}
+	return v, nil
+}
+
+func (f *yamlFlow) node(tag string) (any, error) {
+	if f.i == len(f.s) {
+		return nil, errYAMLUnterminated
+	}
+
+	switch c := f.s[f.i]; c {
+	case '[':
+		f.i++
+		seq := []any{}
+		for {
+			f.skip()
+			if f.i == len(f.s) {
+				return nil, errYAMLUnterminated
+			}
+			if f.s[f.i] == ']' {
+				f.i++
+				return seq, nil
+			}
+
+			v, err := f.value()
+			if err != nil {
+				return nil, err
+			}
+			seq = append(seq, v)
+
+			if err := f.separator(']'); err != nil {
+				return nil, err
+			}
+		}
+	case '{':
+		f.i++
+		m := make(map[string]any)
+		for {
+			f.skip()
+			if f.i == len(f.s) {
+				return nil, errYAMLUnterminated
+			}
+			if f.s[f.i] == '}' {
+				f.i++
+				return m, nil
+			}
+
+			k, err := f.key()
+			if err != nil {
+				return nil, err
+			}
+
+			// Keys without a value are null.
+			var v any
+			f.skip()
+			if f.i < len(f.s) && f.s[f.i] == ':' {
+				f.i++
+				if v, err = f.value(); err != nil {
+					return nil, err
+				}
+			}
+
+			if _, ok := m[k]; ok {
+				return nil, fmt.Errorf("duplicate key %q", k)
+			}
+			m[k] = v
+
+			if err := f.separator('}'); err != nil {
+				return nil, err
+			}
+		}
+	case '"', '\'':
+		s, n, err := parseYAMLQuoted(f.s[f.i:])
+		if err != nil {
+			return nil, err
+		}
+		f.i += n
+		return s, nil
+	case '*':
+		start := f.i + 1
+		for f.i < len(f.s) && !isYAMLFlowIndicator(f.s[f.i]) && !isYAMLSpace(f.s[f.i]) {
+			f.i++
+		}
+
+		v, ok := f.anchors[f.s[start:f.i]]
+		if !ok {
+			return nil, fmt.Errorf("unknown anchor %q", f.s[start:f.i])
+		}
+		return v, nil
+	case ']', '}', ',':
+		return nil, fmt.Errorf("unexpected %q", c)
+	}
+
+	s := f.plain()
+	if tag == "!!str" {
+		return s, nil
+	}
+	return resolveYAMLScalar(s), nil
+}
+
+func (f *yamlFlow) key() (string, error) {
+	if c := f.s[f.i]; c == '"' || c == '\'' {
+		s, n, err := parseYAMLQuoted(f.s[f.i:])
+		if err != nil {
+			return "", err
+		}
+		f.i += n
+		return s, nil
+	}
+	return f.plain(), nil
+}
+
+// separator consumes the comma between the entries of a flow collection.
+func (f *yamlFlow) separator(end byte) error {
+	f.skip()
+	if f.i == len(f.s) {
+		return errYAMLUnterminated
+	}
+
+	switch f.s[f.i] {
+	case ',':
+		f.i++
+		return nil
+	case end:
+		return nil
+	}
+	return fmt.Errorf("expected ',' or '%c', got %q", end, f.s[f.i:])
+}
+
+// plain parses a plain scalar within a flow collection, which ends at a flow
+// indicator, a mapping value indicator, or a comment.
+func (f *yamlFlow) plain() string {
+	start := f.i
+	for ; f.i < len(f.s); f.i++ {
+		c := f.s[f.i]
+		if isYAMLFlowIndicator(c) ||
+			c == ':' && (f.i+1 == len(f.s) || isYAMLSpace(f.s[f.i+1]) || isYAMLFlowIndicator(f.s[f.i+1])) ||
+			c == '#' && f.i > start && isYAMLSpace(f.s[f.i-1]) {
+			break
+		}
+	}
+
+	lines := strings.Split(f.s[start:f.i], "\n")
+	for i, l := range lines {
+		lines[i] = strings.TrimSpace(l)
+	}
+	return strings.Join(lines, " ")
+}
+
+// yamlEscapes maps the single character escape sequences of double quoted
+// YAML scalars to the runes they represent.
+var yamlEscapes = map[byte]rune{
+	'0': 0, 'a': '\a', 'b': '\b', 't': '\t', '\t': '\t', 'n': '\n', 'v': '\v',
+	'f': '\f', 'r': '\r', 'e': '\x1b', ' ': ' ', '"': '"', '/': '/', '\\': '\\',
+	'N': '\u0085', '_': '\u00a0', 'L': '\u2028', 'P': '\u2029',
+}
+
+// parseYAMLQuoted parses the single or double quoted scalar at the start of s,
+// returning its value and the number of bytes consumed. Line breaks within the
+// scalar are folded into spaces.
+func parseYAMLQuoted(s string) (string, int, error) {
+	q := s[0]
+
+	var b []byte
+	for i := 1; i < len(s); {
+		c := s[i]
+		switch {
+		case c == '\'' && q == '\'' && i+1 < len(s) && s[i+1] == '\'':
+			b = append(b, '\'')
+			i += 2
+		case c == q:
+			return string(b), i + 1, nil
+		case c == '\\' && q == '"':
+			if i+1 == len(s) {
+				return "", 0, errYAMLUnterminated
+			}
+
+			// An escaped line break joins the lines without a space.
+			if s[i+1] == '\n' {
+				for i += 2; i < len(s) && (s[i] == ' ' || s[i] == '\t'); i++ {
+				}
+				continue
+			}
+
+			r, n, err := parseEscape(s[i+1:], yamlEscapes)
+			if err != nil {
+				return "", 0, err
+			}
+			b = utf8.AppendRune(b, r)
+			i += 1 + n
+		case c == '\n':
+			b = bytes.TrimRight(b, " \t")
+
+			breaks := 0
+			for ; i < len(s) && (s[i] == ' ' || s[i] == '\t' || s[i] == '\n'); i++ {
+				if s[i] == '\n' {
+					breaks++
+				}
+			}
+
+			if breaks == 1 {
+				b = append(b, ' ')
+			} else {
+				b = append(b, strings.Repeat("\n", breaks-1)...)
+			}
+		default:
+			b = append(b, c)
+			i++
+		}
+	}
+	return "", 0, errYAMLUnterminated
+}
+
+// parseEscape decodes the escape sequence following a backslash at the start
+// of s, returning its rune and the number of bytes consumed. Escapes other
+// than the hexadecimal \x, \u, and \U forms are looked up in simple.
+func parseEscape(s string, simple map[byte]rune) (rune, int, error) {
+	if r, ok := simple[s[0]]; ok {
+		return r, 1, nil
+	}
+
+	var n int
+	switch s[0] {
+	case 'x':
+		n = 2
+	case 'u':
+		n = 4
+	case 'U':
+		n = 8
+	default:
+		return 0, 0, fmt.Errorf("invalid escape sequence \\%c", s[0])
+	}
+
+	if len(s) <= n {
+		return 0, 0, fmt.Errorf("invalid escape sequence \\%s", s)
+	}
+
+	v, err := strconv.ParseUint(s[1:n+1], 16, 32)
+	if err != nil || !utf8.ValidRune(rune(v)) {
+		return 0, 0, fmt.Errorf("invalid escape sequence \\%s", s[:n+1])
+	}
+	return rune(v), n + 1, nil
+}
+
+// yamlProperties splits the anchor and tag properties from the start of a
+// node.
+func yamlProperties(text string) (anchor, tag, rest string) {
+	rest = strings.TrimLeft(text, " \t")
+	for rest != "" && (rest[0] == '&' || rest[0] == '!') {
+		end := strings.IndexAny(rest, " \t")
+		if end < 0 {
+			end = len(rest)
+		}
+
+		if rest[0] == '&' {
+			anchor = rest[1:end]
+		} else {
+			tag = rest[:end]
+		}
+		rest = strings.TrimLeft(rest[end:], " \t")
+	}
+	return anchor, tag, rest
+}
+
+// splitYAMLKey splits a line of a block mapping into its key and the offset
+// of its value.
+func splitYAMLKey(text string) (key string, offset int, ok bool) {
+	if text == "" || isYAMLSequenceItem(text) {
+		return "", 0, false
+	}
+
+	isValueIndicator := func(i int) bool {
+		return i < len(text) && text[i] == ':' && (i+1 == len(text) || isYAMLSpace(text[i+1]))
+	}
+
+	switch text[0] {
+	case '"', '\'':
+		key, n, err := parseYAMLQuoted(text)
+		if err != nil {
+			return "", 0, false
+		}
+
+		for n < len(text) && isYAMLSpace(text[n]) {
+			n++
+		}
+		if !isValueIndicator(n) {
+			return "", 0, false
+		}
+		return key, n + 1, true
+	case '[', '{', '&', '*', '!', '|', '>', '#', '%', '@', '`', '?':
+		return "", 0, false
+	}
+
+	for i := range text {
+		if isValueIndicator(i) {
+			return strings.TrimRight(text[:i], " \t"), i + 1, true
+		}
+	}
+	return "", 0, false
+}
+
+func isYAMLKey(text string) bool {
+	_, _, ok := splitYAMLKey(text)
+	return ok
+}
+
+func isYAMLSequenceItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ") || strings.HasPrefix(text, "-\t")
+}
+
+func isYAMLSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n'
+}
+
+func isYAMLFlowIndicator(c byte) bool {
+	return c == ',' || c == '[' || c == ']' || c == '{' || c == '}'
+}
+
+// stripYAMLComment removes a trailing comment and whitespace from a line. A
+// comment starts with a # at the start of the line or after whitespace, and
+// outside of any quoted scalar.
+func stripYAMLComment(text string) string {
+	var quote byte
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case quote == '"' && c == '\\', quote == '\'' && c == '\'' && i+1 < len(text) && text[i+1] == '\'':
+			i++
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '#' && (i == 0 || isYAMLSpace(text[i-1])):
+			return strings.TrimRight(text[:i], " \t")
+		case (c == '"' || c == '\'') && (i == 0 || strings.IndexByte(" \t:-,[{?", text[i-1]) >= 0):
+			quote = c
+		}
+	}
+	return strings.TrimRight(text, " \t")
+}
+
+var (
+	yamlInteger = regexp.MustCompile(`^[-+]?[0-9]+$`)
+	yamlFloat   = regexp.MustCompile(`^[-+]?(\.[0-9]+|[0-9]+(\.[0-9]*)?)([eE][-+]?[0-9]+)?$`)
+)
+
+// resolveYAMLScalar resolves the value of a plain scalar with the YAML 1.2
+// core schema. Scalars that are not null, booleans, or numbers are strings.
+func resolveYAMLScalar(s string) any {
+	switch s {
+	case "", "~", "null", "Null", "NULL":
+		return nil
+	case "true", "True", "TRUE":
+		return true
+	case "false", "False", "FALSE":
+		return false
+	case ".inf", ".Inf", ".INF", "+.inf", "+.Inf", "+.INF":
+		return math.Inf(1)
+	case "-.inf", "-.Inf", "-.INF":
+		return math.Inf(-1)
+	case ".nan", ".NaN", ".NAN":
+		return math.NaN()
+	}
+
+	switch {
+	case yamlInteger.MatchString(s):
+		if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return n
+		}
+	case strings.HasPrefix(s, "0x"):
+		if n, err := strconv.ParseInt(s[2:], 16, 64); err == nil {
+			return n
+		}
+	case strings.HasPrefix(s, "0o"):
+		if n, err := strconv.ParseInt(s[2:], 8, 64); err == nil {
+			return n
+		}
+	case yamlFloat.MatchString(s):
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return f
+		}
+	}
+	return s
+}
@@ -0,0 +1,279 @@
+package assert
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestYAMLEqual(t *testing.T) {
+	tests := []struct {
+		name               string
+		got                string
+		expected           string
+		expectedMessage    string
+		expectedErrorCalls int
+		expectedFatalCalls int
+	}{
+		{
+			name: "Formatting, comments, and key order",
+			got: `
+kind: Deployment # comment
+metadata: {labels: {app: web}, name: web}
+spec:
+  replicas: 3
+`,
+			expected: `kind: Deployment
+spec: {replicas: 3}
+metadata:
+  name: web
+  labels:
+    app: "web"
+`,
+		},
+		{
+			name:     "Multiple documents",
+			got:      "---\na: 1\n---\nb: 2\n",
+			expected: "a: 1\n---\nb: 2",
+		},
+		{
+			name:     "Block and flow sequences",
+			got:      "items:\n- 1\n- [2, 3]\n",
+			expected: "items: [1, [2, 3]]",
+		},
+		{
+			name:     "NaN and infinities",
+			got:      "a: .nan\nb: [.inf, -.Inf]\n",
+			expected: "a: .NaN\nb: [+.inf, -.inf]\n",
+		},
+		{
+			name:               "NaN and number",
+			got:                "a: .nan\n",
+			expected:           "a: 1.5\n",
+			expectedMessage:    "YAML documents are not equal:\n\t[\"a\"]: expected 1.5, got NaN",
+			expectedErrorCalls: 1,
+		},
+		{
+			name:               "Opposite infinities",
+			got:                "a: -.inf\n",
+			expected:           "a: .inf\n",
+			expectedMessage:    "YAML documents are not equal:\n\t[\"a\"]: expected +Inf, got -Inf",
+			expectedErrorCalls: 1,
+		},
+		{
+			name:               "Differing values",
+			got:                "spec:\n  replicas: 2\n  image: web:1.1\n",
+			expected:           "spec:\n  replicas: 3\n  image: web:1.0\n",
+			expectedMessage:    "YAML documents are not equal:\n\t[\"spec\"][\"image\"]: expected \"web:1.0\", got \"web:1.1\"\n\t[\"spec\"][\"replicas\"]: expected 3, got 2",
+			expectedErrorCalls: 1,
+		},
+		{
+			name:               "Differing types",
+			got:                "port: \"80\"",
+			expected:           "port: 80",
+			expectedMessage:    "YAML documents are not equal:\n\t[\"port\"]: expected 80 (int64), got \"80\" (string)",
+			expectedErrorCalls: 1,
+		},
+		{
+			name:               "Differing documents",
+			got:                "a: 1\n---\nb: 3\n",
+			expected:           "a: 1\n---\nb: 2\n---\nc: 3\n",
+			expectedMessage:    "YAML documents are not equal:\n\t[1][\"b\"]: expected 2, got 3\n\t[2]: missing element map[string]interface {}{\"c\": 3}",
+			expectedErrorCalls: 1,
+		},
+		{
+			name:               "Invalid got",
+			got:                "a: [1, 2",
+			expected:           "a: [1, 2]",
+			expectedErrorCalls: 1,
+		},
+		{
+			name:               "Invalid expected",
+			got:                "a: 1",
+			expected:           "a: 1\na: 2",
+			expectedFatalCalls: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := newMockTB()
+
+			YAMLEqual(mockT, tt.got, tt.expected)
+			n := len(mockT.ErrorfCalls)
+			m := len(mockT.FatalfCalls)
+
+			if n != tt.expectedErrorCalls {
+				t.Fatalf("expected %d calls to Errorf(), got %d", tt.expectedErrorCalls, n)
+			}
+
+			if m != tt.expectedFatalCalls {
+				t.Errorf("expected %d calls to Fatalf(), got %d", tt.expectedFatalCalls, m)
+			}
+
+			if mockT.HelperCalls != n+m {
+				t.Errorf("expected %d calls to Helper(), got %d", n+m, mockT.HelperCalls)
+			}
+
+			if tt.expectedMessage != "" && mockT.ErrorfCalls[0].message() != tt.expectedMessage {
+				t.Errorf("expected message:\n%s\ngot:\n%s", tt.expectedMessage, mockT.ErrorfCalls[0].message())
+			}
+		})
+	}
+}
+
+func TestParseYAML(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []any
+		err      string
+	}{
+		{
+			name:     "Empty stream",
+			input:    "# only a comment\n",
+			expected: []any{},
+		},
+		{
+			name:     "Scalars",
+			input:    "[~, null, true, False, 12, -3, 0x1f, 0o17, 1.5, 1e3, .inf, abc, '1', \"2\", 1.2.3]",
+			expected: []any{[]any{nil, nil, true, false, int64(12), int64(-3), int64(31), int64(15), 1.5, 1000.0, math.Inf(1), "abc", "1", "2", "1.2.3"}},
+		},
+		{
+			name: "Nested block collections",
+			input: `
+spec:
+  containers:
+  - name: web
+    ports:
+      - 80
+      - 443
+  -   name: sidecar
+      # a comment
+      args: []
+empty:
+`,
+			expected: []any{map[string]any{
+				"spec": map[string]any{
+					"containers": []any{
+						map[string]any{"name": "web", "ports": []any{int64(80), int64(443)}},
+						map[string]any{"name": "sidecar", "args": []any{}},
+					},
+				},
+				"empty": nil,
+			}},
+		},
+		{
+			name:     "Nested sequences",
+			input:    "- - a\n  - b\n- - c\n",
+			expected: []any{[]any{[]any{"a", "b"}, []any{"c"}}},
+		},
+		{
+			name:     "Quoted scalars",
+			input:    "a: 'it''s # not a comment'\n\"b c\": \"tab\\tand \\u00e9\"\nd: \"folded\n  line\"\n",
+			expected: []any{map[string]any{"a": "it's # not a comment", "b c": "tab\tand é", "d": "folded line"}},
+		},
+		{
+			name:     "Plain multiline scalar",
+			input:    "a: first\n  second\n\n  third\nb: !!str 123\n",
+			expected: []any{map[string]any{"a": "first second\nthird", "b": "123"}},
+		},
+		{
+			name: "Block scalars",
+			input: `
+literal: |
+  line 1
+    indented
+
+  line 3
+folded: >-
+  some
+  folded text
+
+  new paragraph
+keep: |+
+  text
+
+strip: |-
+  text
+`,
+			expected: []any{map[string]any{
+				"literal": "line 1\n  indented\n\nline 3\n",
+				"folded":  "some folded text\nnew paragraph",
+				"keep":    "text\n\n",
+				"strip":   "text",
+			}},
+		},
+		{
+			name: "Anchors, aliases, and merge keys",
+			input: `
+base: &base
+  image: web
+  replicas: 1
+ports: &ports [80, 443]
+app:
+  <<: *base
+  replicas: 3
+  ports: *ports
+`,
+			expected: []any{map[string]any{
+				"base":  map[string]any{"image": "web", "replicas": int64(1)},
+				"ports": []any{int64(80), int64(443)},
+				"app":   map[string]any{"image": "web", "replicas": int64(3), "ports": []any{int64(80), int64(443)}},
+			}},
+		},
+		{
+			name:     "Multiline flow collection",
+			input:    "a: [1,\n  2, # comment\n  {b: c}\n]\n",
+			expected: []any{map[string]any{"a": []any{int64(1), int64(2), map[string]any{"b": "c"}}}},
+		},
+		{
+			name:     "Documents",
+			input:    "%YAML 1.2\n---\na: 1\n...\n--- text\n---\n",
+			expected: []any{map[string]any{"a": int64(1)}, "text", nil},
+		},
+		{
+			name:  "Duplicate key",
+			input: "a: 1\nb: 2\na: 3\n",
+			err:   `line 3: duplicate key "a"`,
+		},
+		{
+			name:  "Mapping on the line of a key",
+			input: "a: b: c\n",
+			err:   "line 1: mapping values are not allowed here",
+		},
+		{
+			name:  "Unknown anchor",
+			input: "a: *missing\n",
+			err:   `line 1: unknown anchor "missing"`,
+		},
+		{
+			name:  "Bad indentation",
+			input: "a: 1\n  b: 2\n",
+			err:   `line 2: unexpected "b: 2"`,
+		},
+		{
+			name:  "Unterminated flow collection",
+			input: "a: {b: 1\n",
+			err:   "line 1: unterminated flow collection or quoted scalar",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			docs, err := parseYAML([]byte(tt.input))
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Fatalf("expected error %q, got %v", tt.err, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(docs, tt.expected) {
+				t.Errorf("unexpected documents:\n%s", diffValues(tt.expected, docs))
+			}
+		})
+	}
+}